@@ -0,0 +1,55 @@
+#!/bin/bash
+# OUT=/path/to/file.jsonl - output file, default /tmp/{{db}}.artificial.jsonl
+# Writes all artificial events data as JSON lines: {"table": "gha_issues", "event_id": 123, "row": {...}}
+# Each run writes a full snapshot (replacing output file), artificial event IDs are not guaranteed to be increasing and
+# events can be deleted and recreated (GHA2DB_SKIP_UPDATE_EVENTS), so incremental export by ID could miss them.
+# Rows of gha_actors, gha_labels and gha_repos referenced by artificial events are written first (with null event_id),
+# so the log can be imported into a database that doesn't have them.
+# Appending payloads at write time (from ArtificialEvent/ArtificialPREvent) belongs to devstatscode and is not implemented yet,
+# until then this script is scheduled from cron (see crontab.entry) to export after syncs.
+if [ -z "$1" ]
+then
+  echo "$0: you need to provide database name as an argument"
+  exit 1
+fi
+db=$1
+if [ "$db" = "devstats" ]
+then
+  exit 0
+fi
+if [ -z "$OUT" ]
+then
+  OUT="/tmp/$db.artificial.jsonl"
+fi
+from=281474976710656
+refs="with actors as (
+  select actor_id as id from gha_events where id > $from
+  union select user_id from gha_issues where event_id > $from
+  union select assignee_id from gha_issues where event_id > $from
+  union select user_id from gha_pull_requests where event_id > $from
+  union select assignee_id from gha_pull_requests where event_id > $from
+  union select merged_by_id from gha_pull_requests where event_id > $from
+  union select creator_id from gha_milestones where event_id > $from
+  union select assignee_id from gha_issues_assignees where event_id > $from
+  union select assignee_id from gha_pull_requests_assignees where event_id > $from
+  union select requested_reviewer_id from gha_pull_requests_requested_reviewers where event_id > $from
+), labels as (
+  select label_id as id from gha_issues_labels where event_id > $from
+  union select label_id from gha_issues_events_labels where event_id > $from
+), repos as (
+  select repo_id as id from gha_events where id > $from
+)"
+sql="select 'gha_actors' as t, null::bigint as eid, 1 as ord, row_to_json(r) as r from gha_actors r where r.id in (select id from actors)"
+sql="$sql union all select 'gha_labels', null::bigint, 2, row_to_json(r) from gha_labels r where r.id in (select id from labels)"
+sql="$sql union all select 'gha_repos', null::bigint, 3, row_to_json(r) from gha_repos r where r.id in (select id from repos)"
+ord=3
+for tab in gha_events:id gha_payloads:event_id gha_issues:event_id gha_pull_requests:event_id gha_milestones:event_id gha_issues_labels:event_id gha_issues_assignees:event_id gha_pull_requests_assignees:event_id gha_pull_requests_requested_reviewers:event_id gha_issues_events_labels:event_id gha_texts:event_id
+do
+  t=${tab%:*}
+  c=${tab#*:}
+  ord=$((ord+1))
+  sql="$sql union all select '$t', r.$c, $ord, row_to_json(r) from $t r where r.$c > $from"
+done
+db.sh psql "$db" -tAc "$refs select json_build_object('table', t, 'event_id', eid, 'row', r) from ($sql) sub order by eid nulls first, ord" > "$OUT.tmp" || exit 2
+mv "$OUT.tmp" "$OUT" || exit 3
+echo "$db: exported all artificial events to $OUT"
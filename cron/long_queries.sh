@@ -0,0 +1,23 @@
+#!/bin/bash
+# THRESHOLD='5 minutes' - report queries running longer than this, default '10 minutes'
+# USERS='gha_admin,ro_user' - only check queries issued by those database users, default 'gha_admin'
+# CANCEL=1 - also cancel reported queries (pg_cancel_backend), TERMINATE=1 - terminate their backends instead
+if [ -z "$THRESHOLD" ]
+then
+  THRESHOLD='10 minutes'
+fi
+if [ -z "$USERS" ]
+then
+  USERS='gha_admin'
+fi
+users="'${USERS//,/\',\'}'"
+action='null'
+if [ ! -z "$CANCEL" ]
+then
+  action='pg_cancel_backend(pid)'
+fi
+if [ ! -z "$TERMINATE" ]
+then
+  action='pg_terminate_backend(pid)'
+fi
+db.sh psql postgres -tAF $'\t' -c "select now(), pid, datname, usename, application_name, now() - query_start, state, ${action}, regexp_replace(query, '\s+', ' ', 'g') from pg_stat_activity where pid != pg_backend_pid() and state != 'idle' and usename in (${users}) and query_start < now() - '${THRESHOLD}'::interval order by query_start" || exit 1
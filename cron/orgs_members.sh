@@ -0,0 +1,88 @@
+#!/bin/bash
+# Syncs members of all organizations owning database repositories into gha_orgs_members, keeping membership visibility history.
+# Reads /orgs/{org}/members and /orgs/{org}/public_members, members not listed as public are private.
+# When visibility changes or membership ends, current record gets dt_to set and (for a visibility change) a new record is added.
+# Token should belong to an organization member, otherwise GitHub only lists public members and private ones are seen as ended.
+# GHA2DB_GITHUB_OAUTH=... - GitHub token or file containing token(s), default /etc/github/oauths then /etc/github/oauth
+if [ -z "$1" ]
+then
+  echo "$0: you need to provide database name as an argument"
+  exit 1
+fi
+db=$1
+if [ "$db" = "devstats" ]
+then
+  exit 0
+fi
+. github_oauth.sh || exit 4
+function list_all {
+  local page=1
+  local data
+  while true
+  do
+    data=`curl -s -f "${auth[@]}" "$1?per_page=100&page=${page}"` || return 1
+    if [ "`echo "$data" | jq 'length'`" = "0" ]
+    then
+      return 0
+    fi
+    echo "$data" | jq -r '.[] | "\(.id) \(.login)"'
+    page=$((page+1))
+  done
+}
+run_dt=`date -u '+%Y-%m-%d %H:%M:%S'`
+rate_limit_sample.sh orgs_members "$db" "$run_dt" start
+orgs=`db.sh psql "$db" -tAc "select distinct split_part(name, '/', 1) from gha_repos where name like '%_/_%' and name not like '%/%/%' order by 1"` || exit 2
+n=0
+for org in $orgs
+do
+  org_id=`curl -s -f "${auth[@]}" "https://api.github.com/orgs/${org}" | jq -r '.id // empty'`
+  if [ -z "$org_id" ]
+  then
+    echo "$db: $org: not an organization or no access"
+    continue
+  fi
+  members=`list_all "https://api.github.com/orgs/${org}/members"`
+  if [ ! "$?" = "0" ]
+  then
+    echo "$db: $org: cannot get members"
+    continue
+  fi
+  public=`list_all "https://api.github.com/orgs/${org}/public_members"`
+  if [ ! "$?" = "0" ]
+  then
+    echo "$db: $org: cannot get public members"
+    continue
+  fi
+  values=''
+  while read -r id login
+  do
+    if [ -z "$login" ]
+    then
+      continue
+    fi
+    pub=false
+    if [ ! -z "`echo "$public" | grep \"^${id} \"`" ]
+    then
+      pub=true
+    fi
+    if [ ! -z "$values" ]
+    then
+      values="${values},"
+    fi
+    values="${values}(${id}, '${login}', ${pub})"
+  done <<< "$members"
+  if [ -z "$values" ]
+  then
+    values="(null::bigint, null::text, null::boolean)"
+  fi
+  db.sh psql "$db" -1 -v ON_ERROR_STOP=1 -q <<SQL || exit 3
+create temp table curr(actor_id bigint, login text, public boolean) on commit drop;
+insert into curr values ${values};
+delete from curr where actor_id is null;
+update gha_orgs_members m set dt_to = '${run_dt}' where m.org_id = ${org_id} and m.dt_to = '2100-01-01' and not exists (select 1 from curr c where c.actor_id = m.actor_id and c.public = m.public);
+insert into gha_orgs_members(org_id, actor_id, dup_org_login, dup_actor_login, public, dt_from) select ${org_id}, c.actor_id, '${org}', c.login, c.public, '${run_dt}' from curr c where not exists (select 1 from gha_orgs_members m where m.org_id = ${org_id} and m.actor_id = c.actor_id and m.dt_to = '2100-01-01');
+SQL
+  n=$((n+1))
+done
+rate_limit_sample.sh orgs_members "$db" "$run_dt" end
+echo "$db: synced members of $n organizations"
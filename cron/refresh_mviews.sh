@@ -1,5 +1,75 @@
 #!/bin/bash
-db.sh psql gha -c "refresh materialized view current_state.milestones"
-db.sh psql gha -c "refresh materialized view current_state.issue_labels"
-db.sh psql gha -c "refresh materialized view current_state.prs"
-db.sh psql gha -c "refresh materialized view current_state.issues"
+# Refreshes all materialized views in a database (default gha) in dependency order: views reading other materialized views are refreshed after them.
+# Views having a plain (not partial, not expression) unique index are refreshed concurrently, so they stay readable during refresh.
+# Note: it refreshes every materialized view of a database, not only the current_state ones it used to refresh.
+# SCHEMAS='current_state' - only refresh materialized views from those schemas (previous behavior)
+db=$1
+if [ -z "$db" ]
+then
+  db=gha
+fi
+schemas=''
+if [ ! -z "$SCHEMAS" ]
+then
+  schemas="and n.nspname in ('${SCHEMAS//,/\',\'}')"
+fi
+views=`db.sh psql "$db" -tAF ' ' -c "
+with recursive deps as (
+  select distinct rw.ev_class as view_oid,
+    d.refobjid as dep_oid
+  from
+    pg_depend d,
+    pg_rewrite rw,
+    pg_class c
+  where
+    d.classid = 'pg_rewrite'::regclass
+    and d.refclassid = 'pg_class'::regclass
+    and rw.oid = d.objid
+    and c.oid = d.refobjid
+    and c.relkind = 'm'
+    and d.refobjid != rw.ev_class
+), levels as (
+  select oid,
+    0 as level
+  from
+    pg_class
+  where
+    relkind = 'm'
+  union all select d.view_oid,
+    l.level + 1
+  from
+    levels l,
+    deps d
+  where
+    d.dep_oid = l.oid
+)
+select
+  n.nspname || '.' || c.relname,
+  case when c.relispopulated and exists (select 1 from pg_index i where i.indrelid = c.oid and i.indisunique and i.indisvalid and i.indpred is null and i.indexprs is null) then 'concurrently' else '' end
+from
+  levels l,
+  pg_class c,
+  pg_namespace n
+where
+  c.oid = l.oid
+  and n.oid = c.relnamespace
+  ${schemas}
+group by
+  n.nspname,
+  c.relname,
+  c.oid,
+  c.relispopulated
+order by
+  max(l.level),
+  1
+"` || exit 1
+while read -r view mode
+do
+  if [ -z "$view" ]
+  then
+    continue
+  fi
+  echo "`date '+%Y-%m-%d %H:%M:%S'` $db: refreshing $view $mode"
+  db.sh psql "$db" -c "refresh materialized view $mode $view" || exit 2
+done <<< "$views"
+echo "`date '+%Y-%m-%d %H:%M:%S'` $db: materialized views refreshed"
@@ -0,0 +1,44 @@
+#!/bin/bash
+# Saves today's stargazers, forks, subscribers (watchers) and open issues counts of all database repositories into gha_repo_stats.
+# Running it again on the same day replaces that day's values.
+# GHA2DB_GITHUB_OAUTH=... - GitHub token or file containing token(s), default /etc/github/oauths then /etc/github/oauth
+if [ -z "$1" ]
+then
+  echo "$0: you need to provide database name as an argument"
+  exit 1
+fi
+db=$1
+if [ "$db" = "devstats" ]
+then
+  exit 0
+fi
+. github_oauth.sh || exit 4
+run_dt=`date -u '+%Y-%m-%d %H:%M:%S'`
+rate_limit_sample.sh repo_stats "$db" "$run_dt" start
+repos=`db.sh psql "$db" -tAc "select distinct name from gha_repos where name like '%_/_%' and name not like '%/%/%' order by name"` || exit 2
+values=''
+n=0
+for repo in $repos
+do
+  row=`curl -s -f "${auth[@]}" "https://api.github.com/repos/${repo}" | jq -r '[.id, .full_name, .stargazers_count, .forks_count, .subscribers_count, .open_issues_count] | map(tostring) | join(" ")'`
+  if [ -z "$row" ]
+  then
+    echo "$db: $repo: cannot get repository data"
+    continue
+  fi
+  read -r id name stars forks subscribers issues <<< "$row"
+  if [ ! -z "$values" ]
+  then
+    values="${values},"
+  fi
+  values="${values}(${id}, now()::date, '${name}', ${stars}, ${forks}, ${subscribers}, ${issues})"
+  n=$((n+1))
+done
+rate_limit_sample.sh repo_stats "$db" "$run_dt" end
+if [ -z "$values" ]
+then
+  echo "$db: no repository stats fetched"
+  exit 0
+fi
+db.sh psql "$db" -c "insert into gha_repo_stats(repo_id, dt, dup_repo_name, stargazers, forks, subscribers, open_issues) values ${values} on conflict (repo_id, dt) do update set dup_repo_name = excluded.dup_repo_name, stargazers = excluded.stargazers, forks = excluded.forks, subscribers = excluded.subscribers, open_issues = excluded.open_issues" || exit 3
+echo "$db: saved stats of $n repositories"
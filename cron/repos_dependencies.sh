@@ -0,0 +1,44 @@
+#!/bin/bash
+# Saves dependency graph (SBOM) snapshot of all database repositories into gha_repos_dependencies.
+# All repositories fetched in a single run get the same snapshot_dt, repositories without dependency graph are skipped.
+# GHA2DB_GITHUB_OAUTH=... - GitHub token or file containing token(s), default /etc/github/oauths then /etc/github/oauth
+if [ -z "$1" ]
+then
+  echo "$0: you need to provide database name as an argument"
+  exit 1
+fi
+db=$1
+if [ "$db" = "devstats" ]
+then
+  exit 0
+fi
+. github_oauth.sh || exit 4
+run_dt=`date -u '+%Y-%m-%d %H:%M:%S'`
+rate_limit_sample.sh repos_dependencies "$db" "$run_dt" start
+repos=`db.sh psql "$db" -tAF ' ' -c "select distinct on (name) id, name from gha_repos where name like '%_/_%' and name not like '%/%/%' order by name, id desc"` || exit 2
+jqf='def q: if . == null then "null" else "'"'"'" + (tostring | gsub("'"'"'"; "'"''"'")) + "'"'"'" end;
+[.sbom.packages[]
+  | (.externalRefs // [] | map(select(.referenceType == "purl"))[0].referenceLocator) as $purl
+  | select($purl != null)
+  | ($purl | capture("^pkg:(?<pm>[^/]+)/").pm) as $pm
+  | (.licenseConcluded // .licenseDeclared) as $lic
+  | "(\($id), '"'"'\($dt)'"'"', '"'"'\($name)'"'"', \($pm | q), \(.name | sub("^[^:]+:"; "") | q), \(.versionInfo // "" | q), \(if $lic == null or $lic == "NOASSERTION" then null else $lic end | q), '"''"')"
+] | join(",")'
+n=0
+while read -r id repo
+do
+  if [ -z "$repo" ]
+  then
+    continue
+  fi
+  values=`curl -s -f "${auth[@]}" "https://api.github.com/repos/${repo}/dependency-graph/sbom" | jq -r --arg id "$id" --arg name "$repo" --arg dt "$run_dt" "$jqf"`
+  if [ -z "$values" ]
+  then
+    echo "$db: $repo: no dependency graph"
+    continue
+  fi
+  db.sh psql "$db" -c "insert into gha_repos_dependencies(repo_id, snapshot_dt, dup_repo_name, package_manager, name, version, license, manifest) values ${values} on conflict do nothing" || exit 3
+  n=$((n+1))
+done <<< "$repos"
+rate_limit_sample.sh repos_dependencies "$db" "$run_dt" end
+echo "$db: saved dependencies snapshot $run_dt of $n repositories"
@@ -0,0 +1,68 @@
+#!/bin/bash
+# Saves GitHub traffic data (daily views and clones, popular referrers) of all database repositories into gha_repos_traffic and gha_repos_referrers.
+# GitHub only keeps the last 14 days of traffic data, so it should be run daily. Values fetched again for the same day replace previous ones.
+# Traffic API requires a token with push access to the repository, repositories without access are skipped.
+# GHA2DB_GITHUB_OAUTH=... - GitHub token or file containing token(s), default /etc/github/oauths then /etc/github/oauth
+if [ -z "$1" ]
+then
+  echo "$0: you need to provide database name as an argument"
+  exit 1
+fi
+db=$1
+if [ "$db" = "devstats" ]
+then
+  exit 0
+fi
+. github_oauth.sh || exit 6
+if [ -z "${auth[*]}" ]
+then
+  echo "$db: traffic API requires a GitHub token"
+  exit 0
+fi
+run_dt=`date -u '+%Y-%m-%d %H:%M:%S'`
+rate_limit_sample.sh repos_traffic "$db" "$run_dt" start
+repos=`db.sh psql "$db" -tAF ' ' -c "select distinct on (name) id, name from gha_repos where name like '%_/_%' and name not like '%/%/%' order by name, id desc"` || exit 2
+n=0
+while read -r id repo
+do
+  if [ -z "$repo" ]
+  then
+    continue
+  fi
+  api="https://api.github.com/repos/${repo}/traffic"
+  views=`curl -s -f "${auth[@]}" "${api}/views"`
+  clones=`curl -s -f "${auth[@]}" "${api}/clones"`
+  referrers=`curl -s -f "${auth[@]}" "${api}/popular/referrers"`
+  if ( [ -z "$views" ] && [ -z "$clones" ] && [ -z "$referrers" ] )
+  then
+    echo "$db: $repo: no access to traffic data"
+    continue
+  fi
+  if [ ! -z "$views" ]
+  then
+    values=`echo "$views" | jq -r --arg id "$id" --arg name "$repo" '[.views[] | "(\($id), '"'"'\(.timestamp)'"'"', '"'"'\($name)'"'"', \(.count), \(.uniques))"] | join(",")'`
+    if [ ! -z "$values" ]
+    then
+      db.sh psql "$db" -c "insert into gha_repos_traffic(repo_id, dt, dup_repo_name, views, unique_views) values ${values} on conflict (repo_id, dt) do update set dup_repo_name = excluded.dup_repo_name, views = excluded.views, unique_views = excluded.unique_views" || exit 3
+    fi
+  fi
+  if [ ! -z "$clones" ]
+  then
+    values=`echo "$clones" | jq -r --arg id "$id" --arg name "$repo" '[.clones[] | "(\($id), '"'"'\(.timestamp)'"'"', '"'"'\($name)'"'"', \(.count), \(.uniques))"] | join(",")'`
+    if [ ! -z "$values" ]
+    then
+      db.sh psql "$db" -c "insert into gha_repos_traffic(repo_id, dt, dup_repo_name, clones, unique_clones) values ${values} on conflict (repo_id, dt) do update set dup_repo_name = excluded.dup_repo_name, clones = excluded.clones, unique_clones = excluded.unique_clones" || exit 4
+    fi
+  fi
+  if [ ! -z "$referrers" ]
+  then
+    values=`echo "$referrers" | jq -r --arg id "$id" --arg name "$repo" '[.[] | "(\($id), now()::date, '"'"'\(.referrer | gsub("'"'"'"; "'"''"'"))'"'"', '"'"'\($name)'"'"', \(.count), \(.uniques))"] | join(",")'`
+    if [ ! -z "$values" ]
+    then
+      db.sh psql "$db" -c "insert into gha_repos_referrers(repo_id, dt, referrer, dup_repo_name, count, uniques) values ${values} on conflict (repo_id, dt, referrer) do update set dup_repo_name = excluded.dup_repo_name, count = excluded.count, uniques = excluded.uniques" || exit 5
+    fi
+  fi
+  n=$((n+1))
+done <<< "$repos"
+rate_limit_sample.sh repos_traffic "$db" "$run_dt" end
+echo "$db: saved traffic of $n repositories"
@@ -0,0 +1,85 @@
+#!/bin/bash
+# Runs BenchmarkMetrics (metrics_test.go) and compares results with a stored baseline.
+# BASELINE=path - baseline file, default bench/metrics_{{project}}.txt
+# UPDATE=1 - save current results as a new baseline instead of comparing
+# THRESHOLD=20 - fail when any metric is slower than baseline by more than this percent, default 20
+# COUNT=5 - number of benchmark runs, BENCHTIME=10x - go test -benchtime value, defaults 5 and 10x
+# TEST_METRICS='new_contributors,episodic_contributors' - only benchmark selected metrics
+# If benchstat is installed, its comparison is also displayed.
+if [ -z "$PG_PASS" ]
+then
+  echo "$0: you need to set PG_PASS environment variable to run this script"
+  exit 1
+fi
+if [ -z "$GHA2DB_PROJECT" ]
+then
+  GHA2DB_PROJECT=kubernetes
+fi
+if [ -z "$BASELINE" ]
+then
+  BASELINE="bench/metrics_${GHA2DB_PROJECT}.txt"
+fi
+if [ -z "$THRESHOLD" ]
+then
+  THRESHOLD=20
+fi
+if [ -z "$COUNT" ]
+then
+  COUNT=5
+fi
+if [ -z "$BENCHTIME" ]
+then
+  BENCHTIME=10x
+fi
+out="/tmp/bench_metrics_${GHA2DB_PROJECT}.txt"
+PG_DB=dbtest GHA2DB_PROJECT="$GHA2DB_PROJECT" GHA2DB_LOCAL=1 go test metrics_test.go -run '^$' -bench BenchmarkMetrics -benchtime "$BENCHTIME" -count "$COUNT" | tee "$out"
+if [ ! "${PIPESTATUS[0]}" = "0" ]
+then
+  echo "$0: benchmarks failed"
+  exit 2
+fi
+if [ ! -z "$UPDATE" ]
+then
+  mkdir -p `dirname "$BASELINE"` || exit 3
+  cp "$out" "$BASELINE" || exit 4
+  echo "$0: saved new baseline $BASELINE"
+  exit 0
+fi
+if [ ! -f "$BASELINE" ]
+then
+  echo "$0: no baseline $BASELINE, run with UPDATE=1 to create it"
+  exit 5
+fi
+if [ ! -z "`which benchstat`" ]
+then
+  benchstat "$BASELINE" "$out"
+fi
+awk -v threshold="$THRESHOLD" '
+  /^Benchmark/ {
+    name = $1
+    sub(/-[0-9]+$/, "", name)
+    if (FILENAME == ARGV[1]) { base[name] += $3; nbase[name]++ } else { curr[name] += $3; ncurr[name]++ }
+  }
+  END {
+    failed = 0
+    for (name in curr) {
+      if (!(name in base)) {
+        continue
+      }
+      b = base[name] / nbase[name]
+      c = curr[name] / ncurr[name]
+      pct = (c - b) * 100.0 / b
+      if (pct > threshold) {
+        printf "%s: %.0f ns/op -> %.0f ns/op (+%.1f%%)\n", name, b, c, pct
+        failed = 1
+      }
+    }
+    exit failed
+  }
+' "$BASELINE" "$out"
+if [ ! "$?" = "0" ]
+then
+  echo "$0: performance regression above ${THRESHOLD}% detected"
+  exit 6
+fi
+echo "$0: no performance regressions above ${THRESHOLD}%"
@@ -0,0 +1,6 @@
+#!/bin/bash
+. ./devel/all_dbs.sh || exit 2
+for db in $all
+do
+ ./cron/export_artificial.sh "$db" || exit 1
+done
@@ -0,0 +1,98 @@
+#!/bin/bash
+# Generates markdown schema reference of all gha_* tables from a live database.
+# Output: out_dir/README.md (tables index) and out_dir/{{table}}.md (columns and relationships), default out_dir is docs/schema.
+# Relationships are detected by column naming conventions (see util_sql/schema_relations.sql), there are no foreign keys in the schema.
+if [ -z "$1" ]
+then
+  echo "Usage: $0 db_name [out_dir]"
+  exit 1
+fi
+db=$1
+out=$2
+if [ -z "$out" ]
+then
+  out=docs/schema
+fi
+mkdir -p "$out" || exit 2
+columns=`./devel/db.sh psql "$db" -tAF $'\x1f' -f util_sql/schema_columns.sql` || exit 3
+relations=`./devel/db.sh psql "$db" -tAF $'\x1f' -f util_sql/schema_relations.sql` || exit 4
+rm -f "$out"/gha_*.md
+index="$out/README.md"
+echo "# \`$db\` database schema" > "$index"
+echo "" >> "$index"
+echo "Generated by \`devel/gen_schema_docs.sh\` from a live database, do not edit manually." >> "$index"
+echo "" >> "$index"
+echo "Columns prefixed with \`dup_\` (\`dupn_\` when nullable) are duplicated from other tables to save joins, see [USAGE](https://github.com/cncf/devstats/blob/master/USAGE.md)." >> "$index"
+echo "" >> "$index"
+echo "| Table | Columns | Description |" >> "$index"
+echo "|---|---|---|" >> "$index"
+prev=''
+n=0
+function finish_table {
+  if [ -z "$prev" ]
+  then
+    return
+  fi
+  local fn="$out/${prev}.md"
+  local table column ref_table ref_column kind rels refs desc
+  rels=`echo "$relations" | awk -F $'\x1f' -v t="$prev" '$1 == t'`
+  if [ ! -z "$rels" ]
+  then
+    echo "" >> "$fn"
+    echo "# Relationships" >> "$fn"
+    echo "" >> "$fn"
+    while IFS=$'\x1f' read -r table column ref_table ref_column kind
+    do
+      if [ "$kind" = "dup" ]
+      then
+        echo "- \`${column}\` duplicates [${ref_table}](${ref_table}.md).\`${ref_column}\`." >> "$fn"
+      else
+        echo "- \`${column}\` references [${ref_table}](${ref_table}.md).\`${ref_column}\`." >> "$fn"
+      fi
+    done <<< "$rels"
+  fi
+  refs=`echo "$relations" | awk -F $'\x1f' -v t="$prev" '$3 == t { print $1 }' | sort -u`
+  if [ ! -z "$refs" ]
+  then
+    echo "" >> "$fn"
+    echo "# Referenced by" >> "$fn"
+    echo "" >> "$fn"
+    for table in $refs
+    do
+      echo "- [${table}](${table}.md)" >> "$fn"
+    done
+  fi
+  desc=''
+  if [ -f "docs/tables/${prev}.md" ]
+  then
+    desc="[details](https://github.com/cncf/devstats/blob/master/docs/tables/${prev}.md)"
+  fi
+  echo "| [${prev}](${prev}.md) | ${n} | ${desc} |" >> "$index"
+}
+while IFS=$'\x1f' read -r table column data_type nullable default pk description
+do
+  if [ -z "$table" ]
+  then
+    continue
+  fi
+  if [ ! "$table" = "$prev" ]
+  then
+    finish_table
+    prev=$table
+    n=0
+    fn="$out/${table}.md"
+    echo "# \`${table}\` table" > "$fn"
+    echo "" >> "$fn"
+    if [ -f "docs/tables/${table}.md" ]
+    then
+      echo "- Detailed description is [here](https://github.com/cncf/devstats/blob/master/docs/tables/${table}.md)." >> "$fn"
+      echo "" >> "$fn"
+    fi
+    echo "| Column | Type | Nullable | Default | PK | Description |" >> "$fn"
+    echo "|---|---|---|---|---|---|" >> "$fn"
+  fi
+  n=$((n+1))
+  echo "| \`${column}\` | ${data_type} | ${nullable} | ${default//|/\\|} | ${pk} | ${description//|/\\|} |" >> "$fn"
+done <<< "$columns"
+finish_table
+echo "$db: schema reference generated in $out"
@@ -0,0 +1,95 @@
+#!/bin/bash
+# Outputs relationship graph of all gha_* tables from a live database in DOT (default) or PlantUML format.
+# Relationships are detected by column naming conventions (see util_sql/schema_relations.sql):
+# plain ID references (event_id, actor_id, ...) are solid edges, dup_* denormalized copies are dashed edges.
+# Example: ./devel/gen_schema_graph.sh gha dot | dot -Tsvg > schema.svg
+# ALL_COLUMNS=1 - also list all columns inside table nodes
+if [ -z "$1" ]
+then
+  echo "Usage: $0 db_name [dot|plantuml]"
+  exit 1
+fi
+db=$1
+fmt=$2
+if [ -z "$fmt" ]
+then
+  fmt=dot
+fi
+if ( [ ! "$fmt" = "dot" ] && [ ! "$fmt" = "plantuml" ] )
+then
+  echo "$0: unknown format '$fmt', use dot or plantuml"
+  exit 2
+fi
+columns=`./devel/db.sh psql "$db" -tAF $'\x1f' -f util_sql/schema_columns.sql` || exit 3
+relations=`./devel/db.sh psql "$db" -tAF $'\x1f' -f util_sql/schema_relations.sql` || exit 4
+tables=`echo "$columns" | cut -d $'\x1f' -f 1 | sort -u`
+if [ "$fmt" = "dot" ]
+then
+  echo "digraph $db {"
+  echo "  rankdir=LR;"
+  echo "  node [shape=record, fontsize=10];"
+  echo "  edge [fontsize=8];"
+else
+  echo "@startuml"
+  echo "hide circle"
+  echo "skinparam linetype ortho"
+fi
+for table in $tables
+do
+  if [ -z "$table" ]
+  then
+    continue
+  fi
+  cols=`echo "$columns" | awk -F $'\x1f' -v t="$table" -v all="$ALL_COLUMNS" '$1 == t && (all != "" || $6 == "yes") { print ($6 == "yes" ? "*" : "") $2 " : " $3 }'`
+  if [ "$fmt" = "dot" ]
+  then
+    label="${table}"
+    while read -r col
+    do
+      if [ ! -z "$col" ]
+      then
+        label="${label}|${col}\\l"
+      fi
+    done <<< "$cols"
+    echo "  ${table} [label=\"{${label}}\"];"
+  else
+    echo "entity ${table} {"
+    while read -r col
+    do
+      if [ ! -z "$col" ]
+      then
+        echo "  ${col}"
+      fi
+    done <<< "$cols"
+    echo "}"
+  fi
+done
+while IFS=$'\x1f' read -r table column ref_table ref_column kind
+do
+  if [ -z "$table" ]
+  then
+    continue
+  fi
+  if [ "$fmt" = "dot" ]
+  then
+    style='solid'
+    if [ "$kind" = "dup" ]
+    then
+      style='dashed'
+    fi
+    echo "  ${table} -> ${ref_table} [label=\"${column}\", style=${style}];"
+  else
+    arrow='}o--||'
+    if [ "$kind" = "dup" ]
+    then
+      arrow='}o..||'
+    fi
+    echo "${table} ${arrow} ${ref_table} : ${column}"
+  fi
+done <<< "$relations"
+if [ "$fmt" = "dot" ]
+then
+  echo "}"
+else
+  echo "@enduml"
+fi
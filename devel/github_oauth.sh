@@ -0,0 +1,26 @@
+#!/bin/bash
+# Sourced by scripts calling GitHub API, sets:
+# oauth - first GitHub token, empty or "-" means public access
+# auth - curl arguments array with REST API authorization header (empty for public access)
+# GHA2DB_GITHUB_OAUTH=... - GitHub token or file containing token(s), default /etc/github/oauths then /etc/github/oauth
+oauth="${GHA2DB_GITHUB_OAUTH}"
+if [ -z "$oauth" ]
+then
+  if [ -f /etc/github/oauths ]
+  then
+    oauth=/etc/github/oauths
+  else
+    oauth=/etc/github/oauth
+  fi
+fi
+if [ -f "$oauth" ]
+then
+  oauth=`cat "$oauth"`
+  oauth=${oauth%%,*}
+  oauth=${oauth//[[:space:]]/}
+fi
+auth=()
+if ( [ ! -z "$oauth" ] && [ ! "$oauth" = "-" ] )
+then
+  auth=(-H "Authorization: token ${oauth}")
+fi
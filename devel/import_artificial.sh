@@ -0,0 +1,60 @@
+#!/bin/bash
+# Imports JSON lines log created by cron/export_artificial.sh into a given database.
+# It is idempotent: rows of a given artificial event are skipped if that event already has rows in a given table.
+# Referenced gha_actors, gha_labels and gha_repos rows are imported first (existing ones are kept).
+# Import fails if any artificial event in the database references an actor, label or repo missing after import.
+# ALLOW_MISSING=1 - only report missing references instead of failing (for logs exported without referenced rows)
+if ( [ -z "$1" ] || [ -z "$2" ] )
+then
+  echo "Usage: $0 db_name file.jsonl"
+  exit 1
+fi
+db=$1
+fn=`realpath "$2"`
+if [ ! -f "$fn" ]
+then
+  echo "$0: file '$2' not found"
+  exit 2
+fi
+sql="/tmp/$db.import_artificial.sql"
+function finish {
+  rm -f "$sql"
+}
+trap finish EXIT
+echo "create temp table import_artificial(line jsonb);" > "$sql"
+echo "\\copy import_artificial(line) from '$fn' with (format csv, quote e'\\x01', delimiter e'\\x02')" >> "$sql"
+for t in gha_actors gha_labels gha_repos
+do
+  echo "insert into $t select r.* from (select (jsonb_populate_record(null::$t, line->'row')).* from import_artificial where line->>'table' = '$t') r on conflict do nothing;" >> "$sql"
+done
+for tab in gha_events:id gha_payloads:event_id gha_issues:event_id gha_pull_requests:event_id gha_milestones:event_id gha_issues_labels:event_id gha_issues_assignees:event_id gha_pull_requests_assignees:event_id gha_pull_requests_requested_reviewers:event_id gha_issues_events_labels:event_id gha_texts:event_id
+do
+  t=${tab%:*}
+  c=${tab#*:}
+  echo "insert into $t select r.* from (select (jsonb_populate_record(null::$t, line->'row')).* from import_artificial where line->>'table' = '$t') r where r.$c > 281474976710656 and not exists (select 1 from $t x where x.$c = r.$c);" >> "$sql"
+done
+action='exception'
+if [ ! -z "$ALLOW_MISSING" ]
+then
+  action='warning'
+fi
+cat >> "$sql" <<SQL
+do \$\$
+declare
+  missing text;
+begin
+  select string_agg(distinct m, ', ') into missing from (
+    select 'actor ' || e.actor_id as m from gha_events e where e.id > 281474976710656 and not exists (select 1 from gha_actors a where a.id = e.actor_id)
+    union select 'actor ' || i.user_id from gha_issues i where i.event_id > 281474976710656 and not exists (select 1 from gha_actors a where a.id = i.user_id)
+    union select 'actor ' || pr.user_id from gha_pull_requests pr where pr.event_id > 281474976710656 and not exists (select 1 from gha_actors a where a.id = pr.user_id)
+    union select 'label ' || il.label_id from gha_issues_labels il where il.event_id > 281474976710656 and not exists (select 1 from gha_labels l where l.id = il.label_id)
+    union select 'repo ' || e.repo_id from gha_events e where e.id > 281474976710656 and not exists (select 1 from gha_repos r where r.id = e.repo_id)
+  ) sub;
+  if missing is not null then
+    raise $action 'artificial events reference missing rows: %', missing;
+  end if;
+end
+\$\$;
+SQL
+db.sh psql "$db" -1 -v ON_ERROR_STOP=1 -f "$sql" || exit 3
+echo "$db: imported artificial events from $2"
@@ -0,0 +1,156 @@
+#!/bin/bash
+# Scaffolds a new project from its GitHub organization: discovers repositories, adds projects.yaml entry and generates
+# project's setup scripts, repository groups, metrics and Grafana configs from a template project (default keptn).
+# Remaining manual steps (All CNCF, icons, Apache, projects lists, ...) are described in ADDING_NEW_PROJECT.md.
+# NAME='Project Name' - project's display name, default the organization name
+# FROM='2019-01-01' - start date, bounds the initial backfill, default the month when the oldest non-fork repository was created
+# GHA2DB_GITHUB_OAUTH=... - GitHub token (optional, unauthenticated API calls are rate limited to 60/hour)
+# DEPLOY=1 - also create Postgres database and run the initial backfill using devel/create_databases.sh (requires PG_PASS)
+if [ -z "$1" ]
+then
+  echo "Usage: $0 github_org [project_name] [template_project]"
+  exit 1
+fi
+org=$1
+proj=$2
+if [ -z "$proj" ]
+then
+  proj=`echo "$org" | tr '[:upper:]' '[:lower:]' | tr -cd 'a-z0-9'`
+fi
+tmpl=$3
+if [ -z "$tmpl" ]
+then
+  tmpl=keptn
+fi
+if [ -z "$NAME" ]
+then
+  NAME=$org
+fi
+if [[ ! "$proj" =~ ^[a-z0-9_-]+$ ]]
+then
+  echo "$0: project name '$proj' can only contain lowercase letters, digits, '_' and '-'"
+  exit 15
+fi
+if [[ "$NAME" == *$'\n'* ]]
+then
+  echo "$0: NAME cannot contain new lines"
+  exit 16
+fi
+if ( [ -e "$proj" ] || [ ! -z "`grep \"^  ${proj}:$\" projects.yaml`" ] )
+then
+  echo "$0: project $proj already exists"
+  exit 2
+fi
+if ( [ ! -f "${tmpl}/psql.sh" ] || [ -z "`grep \"^  ${tmpl}:$\" projects.yaml`" ] )
+then
+  echo "$0: template project $tmpl not found"
+  exit 3
+fi
+tmpl_name=`awk -v t="  ${tmpl}:" '$0 == t { found = 1; next } found && /^    name:/ { sub(/^    name: */, ""); print; exit }' projects.yaml`
+auth=()
+if [ ! -z "$GHA2DB_GITHUB_OAUTH" ]
+then
+  auth=(-H "Authorization: token ${GHA2DB_GITHUB_OAUTH}")
+fi
+repos=''
+page=1
+while true
+do
+  data=`curl -s -f "${auth[@]}" "https://api.github.com/orgs/${org}/repos?type=public&per_page=100&page=${page}"` || exit 4
+  rows=`echo "$data" | jq -r '.[] | select(.fork | not) | [.full_name, .created_at, (.stargazers_count | tostring), (.archived | tostring)] | join(" ")'` || exit 5
+  if [ -z "$rows" ]
+  then
+    if [ "`echo \"$data\" | jq length`" = "0" ]
+    then
+      break
+    fi
+  else
+    repos="${repos}${rows}"$'\n'
+  fi
+  page=$((page+1))
+done
+if [ -z "$repos" ]
+then
+  echo "$0: no public non-fork repositories found in $org"
+  exit 6
+fi
+main_repo=`echo -n "$repos" | sort -k4,4 -k3,3nr | head -n 1 | cut -d ' ' -f 1`
+if [ -z "$FROM" ]
+then
+  FROM=`echo -n "$repos" | cut -d ' ' -f 2 | sort | head -n 1 | cut -c 1-7`-01
+fi
+if [[ ! "$FROM" =~ ^[0-9]{4}-[0-9]{2}-[0-9]{2}$ ]]
+then
+  echo "$0: start date '$FROM' must be in YYYY-MM-DD format"
+  exit 17
+fi
+order=`grep '^    order:' projects.yaml | awk '$2 < 254 { print $2 }' | sort -n | tail -n 1`
+order=$((order+1))
+echo "$proj: $org has `echo -n \"$repos\" | wc -l` repositories, main repository $main_repo, start date $FROM"
+cat >> projects.yaml <<EOP
+  ${proj}:
+    order: ${order}
+    name: ${NAME}
+    status: Sandbox
+    command_line:
+      - ${org}
+    start_date: ${FROM}T00:00:00Z
+    join_date: `date +%Y-%m-%d`T00:00:00Z
+    psql_db: ${proj}
+    shared_db: allprj
+    main_repo: ${main_repo}
+    annotation_regexp: '^v?\d+\.\d+\.\d+$'
+    files_skip_pattern: '(^|/)_?(vendor|Godeps|_workspace)/'
+EOP
+function sed_pattern {
+  printf '%s' "$1" | sed -e 's/[]\/$*.^[]/\\&/g'
+}
+function sed_replacement {
+  printf '%s' "$1" | sed -e 's/[\/&]/\\&/g'
+}
+from_name=`sed_pattern "$tmpl_name"`
+to_name=`sed_replacement "$NAME"`
+function copy_replace {
+  mkdir -p "$2" || exit 7
+  for f in `ls "$1"`
+  do
+    if [ -f "$1/$f" ]
+    then
+      sed -e "s/${from_name}/${to_name}/g" -e "s/${tmpl}/${proj}/g" "$1/$f" > "$2/$f" || exit 8
+      chmod --reference="$1/$f" "$2/$f" || exit 9
+    fi
+  done
+}
+copy_replace "$tmpl" "$proj"
+sed -i -E "s/gha2db [0-9]{4}-[0-9]{2}-[0-9]{2} 0 today now/gha2db ${FROM} 0 today now/" "${proj}/psql.sh" || exit 10
+copy_replace "metrics/${tmpl}" "metrics/${proj}"
+copy_replace "grafana/${tmpl}" "grafana/${proj}"
+copy_replace "grafana/dashboards/${tmpl}" "grafana/dashboards/${proj}"
+mkdir -p "scripts/${proj}" || exit 11
+sed -n '/^update gha_repos set repo_group = alias;$/q;p' scripts/shared/repo_groups.sql > "scripts/${proj}/repo_groups.sql" || exit 12
+echo "update gha_repos set repo_group = alias;" >> "scripts/${proj}/repo_groups.sql"
+echo "" >> "scripts/${proj}/repo_groups.sql"
+echo "-- Starter repository groups generated from ${org} repositories: every repository is its own group, archived ones are grouped together." >> "scripts/${proj}/repo_groups.sql"
+archived=`echo -n "$repos" | awk '$4 == "true" { print "  '"'"'" $1 "'"'"'," }' | sort`
+if [ ! -z "$archived" ]
+then
+  echo "update gha_repos" >> "scripts/${proj}/repo_groups.sql"
+  echo "set repo_group = 'Archived'" >> "scripts/${proj}/repo_groups.sql"
+  echo "where name in (" >> "scripts/${proj}/repo_groups.sql"
+  echo "${archived%,}" >> "scripts/${proj}/repo_groups.sql"
+  echo ");" >> "scripts/${proj}/repo_groups.sql"
+fi
+echo "" >> "scripts/${proj}/repo_groups.sql"
+sed -n '/^select$/,$p' scripts/shared/repo_groups.sql >> "scripts/${proj}/repo_groups.sql"
+echo "$proj: generated ${proj}/, metrics/${proj}/, grafana/${proj}/, grafana/dashboards/${proj}/, scripts/${proj}/repo_groups.sql and projects.yaml entry"
+if [ ! -z "$DEPLOY" ]
+then
+  if [ -z "$PG_PASS" ]
+  then
+    echo "$0: you need to set PG_PASS environment variable to deploy"
+    exit 13
+  fi
+  PROJ=$proj PROJDB=$proj PDB=1 TSDB=1 ./devel/create_databases.sh || exit 14
+  echo "$proj: database created and backfilled from $FROM"
+fi
+echo "$proj: follow ADDING_NEW_PROJECT.md for the remaining steps"
@@ -0,0 +1,78 @@
+#!/bin/bash
+# NOLEADER=1 - skip leader election and just run the command
+# LEADER_DB=... - database used to hold the lock, default devstats
+# Runs command only if we hold a Postgres advisory lock named lock_name.
+# Lock is held by a psql session that lives as long as the command runs, if this replica dies
+# the session is closed, lock is released and the next run on another replica takes over.
+# Failover is not immediate: standby replicas only try the lock when cron starts them, so after the leader dies
+# up to one cron interval (one hour for the hourly sync) can pass before another replica runs the command.
+# There is no lease based (for example Kubernetes Lease) election with a standby taking over while the leader run is due.
+# LEADER_CHECK=n - check that the lock session is alive every n seconds while the command runs, default 30
+# If the lock session is lost (psql exits or server connection is closed), the command is killed, so another
+# replica that acquires the lock never runs concurrently with it.
+if ([ -z "$1" ] || [ -z "$2" ])
+then
+  echo "Usage: $0 lock_name command [args]"
+  exit 1
+fi
+lock=$1
+shift
+if [ ! -z "$NOLEADER" ]
+then
+  "$@"
+  exit $?
+fi
+if [ -z "$LEADER_DB" ]
+then
+  LEADER_DB=devstats
+fi
+coproc PSQL { db.sh psql "$LEADER_DB" -tAq 2>&1; }
+echo "select pg_try_advisory_lock(hashtext('$lock'));" >&${PSQL[1]}
+read -r -t 60 got <&${PSQL[0]}
+if [ ! "$got" = "t" ]
+then
+  if [ "$got" = "f" ]
+  then
+    echo "$0: another replica holds '$lock' lock, skipping"
+    exec {PSQL[1]}>&-
+    wait $PSQL_PID
+    exit 0
+  fi
+  echo "$0: cannot acquire '$lock' lock: '$got'"
+  kill $PSQL_PID 2>/dev/null
+  exit 2
+fi
+echo "$0: acquired '$lock' lock, running: $*"
+if [ -z "$LEADER_CHECK" ]
+then
+  LEADER_CHECK=30
+fi
+psql_pid=$PSQL_PID
+exec {psql_in}>&${PSQL[1]} {psql_out}<&${PSQL[0]} {PSQL[1]}>&- {PSQL[0]}<&-
+"$@" {psql_in}>&- {psql_out}<&- &
+cmd_pid=$!
+while kill -0 $cmd_pid 2>/dev/null
+do
+  sleep "$LEADER_CHECK" &
+  wait $!
+  kill -0 $cmd_pid 2>/dev/null || break
+  alive=''
+  if kill -0 $psql_pid 2>/dev/null
+  then
+    echo "select 1;" >&${psql_in} 2>/dev/null && read -r -t 60 alive <&${psql_out}
+  fi
+  if [ ! "$alive" = "1" ]
+  then
+    echo "$0: lost '$lock' lock session: '$alive', killing: $*"
+    kill $cmd_pid 2>/dev/null
+    wait $cmd_pid
+    kill $psql_pid 2>/dev/null
+    exit 3
+  fi
+done
+wait $cmd_pid
+st=$?
+echo "select pg_advisory_unlock(hashtext('$lock'));" >&${psql_in}
+exec {psql_in}>&- {psql_out}<&-
+wait $psql_pid
+exit $st
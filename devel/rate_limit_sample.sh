@@ -0,0 +1,24 @@
+#!/bin/bash
+# Records current GitHub API core rate limit (limit, remaining, reset) in devstats database gha_rate_limits table.
+# Call it before and after each phase of scripts using GitHub API, see util_sh/rate_limits_usage.sh for API points usage report.
+# Token is identified by first 16 characters of its md5 hash (empty when no token is used).
+# GHA2DB_GITHUB_OAUTH=... - GitHub token or file containing token(s), default /etc/github/oauths then /etc/github/oauth
+if ( [ -z "$1" ] || [ -z "$2" ] || [ -z "$3" ] )
+then
+  echo "Usage: $0 prog proj run_dt [phase]"
+  exit 1
+fi
+. github_oauth.sh || exit 2
+token=''
+if [ ! -z "${auth[*]}" ]
+then
+  token=`echo -n "$oauth" | md5sum | cut -c 1-16`
+fi
+row=`curl -s -f "${auth[@]}" https://api.github.com/rate_limit | jq -r '.resources.core | [.limit, .remaining, .reset] | map(tostring) | join(" ")'`
+if [ -z "$row" ]
+then
+  echo "$0: cannot get rate limit"
+  exit 3
+fi
+read -r lim remaining reset <<< "$row"
+db.sh psql devstats -c "insert into gha_rate_limits(prog, proj, run_dt, phase, token, lim, remaining, reset_at) values ('$1', '$2', '$3', '$4', '$token', $lim, $remaining, to_timestamp($reset) at time zone 'UTC')" > /dev/null || exit 4
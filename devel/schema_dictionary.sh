@@ -0,0 +1,114 @@
+#!/bin/bash
+# Populates gha_schema_dictionary table in a given database, so analysts querying the database directly have in-band documentation.
+# Columns are read from the live database, descriptions from docs/tables/*.md ("- `column`: description" lines),
+# sources of dup_* columns and ID references from column naming conventions (util_sql/schema_relations.sql).
+# Can be run after each structure change, rows of dropped columns are removed.
+if [ -z "$1" ]
+then
+  echo "Usage: $0 db_name"
+  exit 1
+fi
+db=$1
+descs="/tmp/$db.schema_dictionary_descs.tsv"
+rels="/tmp/$db.schema_dictionary_rels.tsv"
+sql="/tmp/$db.schema_dictionary.sql"
+function finish {
+  rm -f "$descs" "$rels" "$sql"
+}
+trap finish EXIT
+./devel/db.sh psql "$db" -v ON_ERROR_STOP=1 -f util_sql/schema_dictionary_table.sql || exit 2
+awk '
+  FNR == 1 {
+    table = FILENAME
+    sub(/^.*\//, "", table)
+    sub(/\.md$/, "", table)
+  }
+  /^#/ {
+    n = split($0, parts, "`")
+    if (n == 3 && parts[2] ~ /^gha_[a-z_0-9]+$/) {
+      table = parts[2]
+    }
+    next
+  }
+  /^- `[a-z_0-9]+`:/ {
+    column = $0
+    sub(/^- `/, "", column)
+    sub(/`:.*$/, "", column)
+    desc = $0
+    sub(/^- `[a-z_0-9]+`: */, "", desc)
+    gsub(/\t/, " ", desc)
+    key = table "." column
+    if (!(key in seen)) {
+      seen[key] = 1
+      print table "\x02" column "\x02" desc
+    }
+  }
+' docs/tables/*.md > "$descs" || exit 3
+./devel/db.sh psql "$db" -tAF $'\x02' -f util_sql/schema_relations.sql > "$rels" || exit 4
+echo "create temp table dict_descs(table_name text, column_name text, description text);" > "$sql"
+echo "create temp table dict_rels(table_name text, column_name text, ref_table text, ref_column text, kind text);" >> "$sql"
+echo "\\copy dict_descs from '$descs' with (format csv, quote e'\\x01', delimiter e'\\x02')" >> "$sql"
+echo "\\copy dict_rels from '$rels' with (format csv, quote e'\\x01', delimiter e'\\x02')" >> "$sql"
+cat >> "$sql" <<'SQL'
+insert into gha_schema_dictionary(table_name, column_name, data_type, nullable, description, source, updated_at)
+select
+  c.table_name,
+  c.column_name,
+  case
+    when c.character_maximum_length is not null then c.data_type || '(' || c.character_maximum_length || ')'
+    else c.data_type
+  end,
+  c.is_nullable = 'YES',
+  coalesce(
+    d.description,
+    col_description((quote_ident(c.table_schema) || '.' || quote_ident(c.table_name))::regclass, c.ordinal_position::int)
+  ),
+  case r.kind
+    when 'dup' then 'duplicated from ' || r.ref_table || '.' || r.ref_column
+    when 'ref' then 'references ' || r.ref_table || '.' || r.ref_column
+  end,
+  now()
+from
+  information_schema.columns c
+join
+  information_schema.tables t
+on
+  t.table_schema = c.table_schema
+  and t.table_name = c.table_name
+  and t.table_type = 'BASE TABLE'
+left join
+  dict_descs d
+on
+  d.table_name = c.table_name
+  and d.column_name = c.column_name
+left join
+  dict_rels r
+on
+  r.table_name = c.table_name
+  and r.column_name = c.column_name
+where
+  c.table_schema = 'public'
+  and c.table_name like 'gha_%'
+on conflict (table_name, column_name) do update set
+  data_type = excluded.data_type,
+  nullable = excluded.nullable,
+  description = excluded.description,
+  source = excluded.source,
+  updated_at = excluded.updated_at
+;
+delete from
+  gha_schema_dictionary d
+where
+  not exists (
+    select 1
+    from
+      information_schema.columns c
+    where
+      c.table_schema = 'public'
+      and c.table_name = d.table_name
+      and c.column_name = d.column_name
+  )
+;
+SQL
+./devel/db.sh psql "$db" -1 -v ON_ERROR_STOP=1 -f "$sql" || exit 5
+echo "$db: gha_schema_dictionary updated"
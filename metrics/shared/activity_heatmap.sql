@@ -0,0 +1,61 @@
+with events as (
+  select ev.id,
+    ev.actor_id,
+    ev.created_at,
+    ev.repo_id,
+    ev.dup_repo_name,
+    ev.created_at + coalesce(a.tz_offset, 0) * '1 minute'::interval as local_dt
+  from
+    gha_events ev
+  left join
+    gha_actors a
+  on
+    a.id = ev.actor_id
+    and a.login = ev.dup_actor_login
+  where
+    {{period:ev.created_at}}
+    and (lower(ev.dup_actor_login) {{exclude_bots}})
+    and ev.type in (
+      'PullRequestReviewCommentEvent', 'PullRequestReviewEvent', 'PushEvent', 'PullRequestEvent',
+      'IssuesEvent', 'IssueCommentEvent', 'CommitCommentEvent'
+    )
+)
+select
+  'hheat,All' as series,
+  to_char(local_dt, 'ID') || '`' || to_char(local_dt, 'HH24') as dow_hour,
+  count(id) as events
+from
+  events
+group by
+  dow_hour
+union select 'hheat,' || r.repo_group as series,
+  to_char(e.local_dt, 'ID') || '`' || to_char(e.local_dt, 'HH24') as dow_hour,
+  count(e.id) as events
+from
+  events e,
+  gha_repos r
+where
+  r.id = e.repo_id
+  and r.name = e.dup_repo_name
+  and r.repo_group is not null
+group by
+  r.repo_group,
+  dow_hour
+union select 'hheat_comp,' || af.company_name as series,
+  to_char(e.local_dt, 'ID') || '`' || to_char(e.local_dt, 'HH24') as dow_hour,
+  count(e.id) as events
+from
+  events e,
+  gha_actors_affiliations af
+where
+  af.actor_id = e.actor_id
+  and af.dt_from <= e.created_at
+  and af.dt_to > e.created_at
+  and af.company_name in (select companies_name from tcompanies)
+group by
+  af.company_name,
+  dow_hour
+order by
+  series asc,
+  dow_hour asc
+;
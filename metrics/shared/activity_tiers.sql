@@ -0,0 +1,54 @@
+with tiers as (
+  select coalesce((select value_i from gha_vars where name = 'activity_tier_regular'), 10) as regular,
+    coalesce((select value_i from gha_vars where name = 'activity_tier_core'), 50) as core
+), contributions as (
+  select e.actor_id,
+    coalesce(r.repo_group, '') as repo_group,
+    e.id
+  from
+    gha_events e
+  left join
+    gha_repos r
+  on
+    r.id = e.repo_id
+    and r.name = e.dup_repo_name
+  where
+    e.type in (
+      'PullRequestReviewCommentEvent', 'PushEvent', 'PullRequestEvent',
+      'IssuesEvent', 'IssueCommentEvent', 'CommitCommentEvent', 'PullRequestReviewEvent'
+    )
+    and e.created_at >= '{{from}}'
+    and e.created_at < '{{to}}'
+    and (lower(e.dup_actor_login) {{exclude_bots}})
+), actors as (
+  select 'All' as repo_group,
+    actor_id,
+    count(distinct id) as contributions
+  from
+    contributions
+  group by
+    actor_id
+  union select repo_group,
+    actor_id,
+    count(distinct id) as contributions
+  from
+    contributions
+  where
+    repo_group != ''
+  group by
+    repo_group,
+    actor_id
+)
+select
+  'atiers;' || a.repo_group || ';drive_by,regular,core' as name,
+  count(a.actor_id) filter (where a.contributions < t.regular) as drive_by,
+  count(a.actor_id) filter (where a.contributions >= t.regular and a.contributions < t.core) as regular,
+  count(a.actor_id) filter (where a.contributions >= t.core) as core
+from
+  actors a,
+  tiers t
+group by
+  a.repo_group
+order by
+  name asc
+;
@@ -0,0 +1,66 @@
+with contributions as (
+  select e.id,
+    coalesce(r.repo_group, '') as repo_group,
+    af.company_name as company
+  from
+    gha_events e
+  join
+    gha_actors_affiliations af
+  on
+    af.actor_id = e.actor_id
+    and af.dt_from <= e.created_at
+    and af.dt_to > e.created_at
+    and af.company_name not in ('(Unknown)', 'NotFound', 'Independent', '')
+  left join
+    gha_repos r
+  on
+    r.id = e.repo_id
+    and r.name = e.dup_repo_name
+  where
+    e.type in (
+      'PullRequestReviewCommentEvent', 'PushEvent', 'PullRequestEvent',
+      'IssuesEvent', 'IssueCommentEvent', 'CommitCommentEvent', 'PullRequestReviewEvent'
+    )
+    and e.created_at >= '{{from}}'
+    and e.created_at < '{{to}}'
+    and (lower(e.dup_actor_login) {{exclude_bots}})
+), companies as (
+  select 'All' as repo_group,
+    company,
+    count(distinct id) as contributions
+  from
+    contributions
+  group by
+    company
+  union select repo_group,
+    company,
+    count(distinct id) as contributions
+  from
+    contributions
+  where
+    repo_group != ''
+  group by
+    repo_group,
+    company
+), shares as (
+  select repo_group,
+    contributions,
+    contributions::float / sum(contributions) over (partition by repo_group) as share,
+    sum(contributions) over (partition by repo_group order by contributions desc, company asc) as cumulative,
+    sum(contributions) over (partition by repo_group) as total
+  from
+    companies
+)
+select
+  'cdiv;' || repo_group || ';companies,hhi,top1_share,elephant_factor' as name,
+  count(*) as companies,
+  round((10000.0 * sum(share * share))::numeric, 2) as hhi,
+  round((100.0 * max(share))::numeric, 2) as top1_share,
+  count(*) filter (where cumulative - contributions < total / 2.0) as elephant_factor
+from
+  shares
+group by
+  repo_group
+order by
+  name asc
+;
@@ -0,0 +1,136 @@
+with data as (
+  select 'commits' as kind,
+    c.sha as item,
+    c.dup_repo_id as repo_id,
+    c.dup_repo_name as repo_name,
+    c.dup_actor_id as actor_id,
+    c.dup_created_at as created_at
+  from
+    gha_commits c
+  where
+    c.dup_created_at >= '{{from}}'
+    and c.dup_created_at < '{{to}}'
+    and (lower(c.dup_actor_login) {{exclude_bots}})
+  union all select 'prs' as kind,
+    pr.id::text as item,
+    pr.dup_repo_id as repo_id,
+    pr.dup_repo_name as repo_name,
+    pr.user_id as actor_id,
+    pr.created_at
+  from
+    gha_pull_requests pr
+  where
+    pr.created_at >= '{{from}}'
+    and pr.created_at < '{{to}}'
+    and (lower(pr.dup_user_login) {{exclude_bots}})
+  union all select 'issues' as kind,
+    i.id::text as item,
+    i.dup_repo_id as repo_id,
+    i.dup_repo_name as repo_name,
+    i.user_id as actor_id,
+    i.created_at
+  from
+    gha_issues i
+  where
+    i.is_pull_request = false
+    and i.created_at >= '{{from}}'
+    and i.created_at < '{{to}}'
+    and (lower(i.dup_user_login) {{exclude_bots}})
+  union all select 'reviews' as kind,
+    e.id::text as item,
+    e.repo_id,
+    e.dup_repo_name as repo_name,
+    e.actor_id,
+    e.created_at
+  from
+    gha_events e
+  where
+    e.type in ('PullRequestReviewEvent', 'PullRequestReviewCommentEvent')
+    and e.created_at >= '{{from}}'
+    and e.created_at < '{{to}}'
+    and (lower(e.dup_actor_login) {{exclude_bots}})
+), affiliated as (
+  select d.kind,
+    d.item,
+    r.repo_group,
+    coalesce(af.company_name, '') as company
+  from
+    data d
+  left join
+    gha_repos r
+  on
+    r.id = d.repo_id
+    and r.name = d.repo_name
+  left join
+    gha_actors_affiliations af
+  on
+    af.actor_id = d.actor_id
+    and af.dt_from <= d.created_at
+    and af.dt_to > d.created_at
+), grouped as (
+  select company,
+    'all' as repo_group,
+    count(distinct item) filter (where kind = 'commits') as commits,
+    count(distinct item) filter (where kind = 'prs') as prs,
+    count(distinct item) filter (where kind = 'issues') as issues,
+    count(distinct item) filter (where kind = 'reviews') as reviews
+  from
+    affiliated
+  group by
+    company
+  union select company,
+    repo_group,
+    count(distinct item) filter (where kind = 'commits') as commits,
+    count(distinct item) filter (where kind = 'prs') as prs,
+    count(distinct item) filter (where kind = 'issues') as issues,
+    count(distinct item) filter (where kind = 'reviews') as reviews
+  from
+    affiliated
+  where
+    repo_group is not null
+  group by
+    company,
+    repo_group
+), totals as (
+  select repo_group,
+    sum(commits) as commits,
+    sum(prs) as prs,
+    sum(issues) as issues,
+    sum(reviews) as reviews
+  from
+    grouped
+  group by
+    repo_group
+)
+select
+  concat('cvel;', g.company, '`', g.repo_group, ';commits,prs,issues,reviews,commits_share,prs_share,issues_share,reviews_share'),
+  round(g.commits / {{n}}, 2) as commits,
+  round(g.prs / {{n}}, 2) as prs,
+  round(g.issues / {{n}}, 2) as issues,
+  round(g.reviews / {{n}}, 2) as reviews,
+  case t.commits when 0 then 0 else round(100.0 * g.commits / t.commits, 2) end as commits_share,
+  case t.prs when 0 then 0 else round(100.0 * g.prs / t.prs, 2) end as prs_share,
+  case t.issues when 0 then 0 else round(100.0 * g.issues / t.issues, 2) end as issues_share,
+  case t.reviews when 0 then 0 else round(100.0 * g.reviews / t.reviews, 2) end as reviews_share
+from
+  grouped g,
+  totals t
+where
+  g.repo_group = t.repo_group
+  and g.company in (select companies_name from tcompanies)
+union select
+  concat('cvel;All`', t.repo_group, ';commits,prs,issues,reviews,commits_share,prs_share,issues_share,reviews_share'),
+  round(t.commits / {{n}}, 2) as commits,
+  round(t.prs / {{n}}, 2) as prs,
+  round(t.issues / {{n}}, 2) as issues,
+  round(t.reviews / {{n}}, 2) as reviews,
+  100 as commits_share,
+  100 as prs_share,
+  100 as issues_share,
+  100 as reviews_share
+from
+  totals t
+order by
+  commits desc,
+  prs desc
+;
@@ -0,0 +1,82 @@
+with finished as (
+  select distinct on (s.deployment_id) s.deployment_id,
+    s.state,
+    s.created_at
+  from
+    gha_deployments_statuses s
+  where
+    s.state in ('success', 'failure', 'error')
+    and s.created_at < '{{to}}'
+  order by
+    s.deployment_id,
+    s.created_at desc,
+    s.id desc
+), deployments as (
+  select d.id,
+    d.environment,
+    coalesce(r.repo_group, '') as repo_group,
+    f.state,
+    extract(epoch from f.created_at - d.created_at) / 3600 as duration
+  from
+    gha_deployments d
+  join
+    finished f
+  on
+    f.deployment_id = d.id
+    and f.created_at >= '{{from}}'
+  left join
+    gha_repos r
+  on
+    r.id = d.dup_repo_id
+    and r.name = d.dup_repo_name
+  where
+    (lower(d.dup_creator_login) {{exclude_bots}})
+), groups as (
+  select 'All' as repo_group,
+    'All' as environment,
+    id,
+    state,
+    duration
+  from
+    deployments
+  union all select 'All' as repo_group,
+    environment,
+    id,
+    state,
+    duration
+  from
+    deployments
+  union all select repo_group,
+    'All' as environment,
+    id,
+    state,
+    duration
+  from
+    deployments
+  where
+    repo_group != ''
+  union all select repo_group,
+    environment,
+    id,
+    state,
+    duration
+  from
+    deployments
+  where
+    repo_group != ''
+)
+select
+  'deploys;' || repo_group || '`' || environment || ';deployments,successful,failed,failure_rate,duration_med' as name,
+  round(count(distinct id) / {{n}}, 2) as deployments,
+  round(count(distinct id) filter (where state = 'success') / {{n}}, 2) as successful,
+  round(count(distinct id) filter (where state != 'success') / {{n}}, 2) as failed,
+  round(100.0 * count(distinct id) filter (where state != 'success') / count(distinct id), 2) as failure_rate,
+  percentile_disc(0.5) within group (order by duration asc) as duration_med
+from
+  groups
+group by
+  repo_group,
+  environment
+order by
+  name asc
+;
@@ -0,0 +1,35 @@
+with events as (
+  select count(*) filter (where id < 281474976710656) as gha_events,
+    count(*) filter (where id > 281474976710656) as artificial_events
+  from
+    gha_events
+  where
+    created_at >= '{{from}}'
+    and created_at < '{{to}}'
+), lag as (
+  select coalesce(extract(epoch from '{{to}}'::timestamp - max(created_at)) / 3600, 168) as sync_lag
+  from
+    gha_events
+  where
+    id < 281474976710656
+    and created_at >= '{{to}}'::timestamp - '1 week'::interval
+    and created_at < '{{to}}'
+), failed as (
+  select count(*) as failed_writes
+  from
+    gha_failed_writes
+  where
+    dt >= '{{from}}'
+    and dt < '{{to}}'
+)
+select
+  'dshealth;All;gha_events,artificial_events,failed_writes,sync_lag' as name,
+  e.gha_events,
+  e.artificial_events,
+  f.failed_writes,
+  round(l.sync_lag::numeric, 2) as sync_lag
+from
+  events e,
+  lag l,
+  failed f
+;
@@ -0,0 +1,26 @@
+with firsts as (
+  select repo_group,
+    actor_id,
+    first_merged_at,
+    extract(epoch from first_merged_at - first_pr_at) / 3600 as to_merge
+  from
+    gha_first_contributions
+  where
+    first_pr_at >= '{{from}}'
+    and first_pr_at < '{{to}}'
+    and (lower(dup_actor_login) {{exclude_bots}})
+)
+select
+  'fcontrib;' || repo_group || ';new,merged,merge_rate,to_merge_med,to_merge_p85' as name,
+  round(count(actor_id) / {{n}}, 2) as new,
+  round(count(first_merged_at) / {{n}}, 2) as merged,
+  round(100.0 * count(first_merged_at) / count(actor_id), 2) as merge_rate,
+  coalesce(percentile_disc(0.5) within group (order by to_merge asc), 0) as to_merge_med,
+  coalesce(percentile_disc(0.85) within group (order by to_merge asc), 0) as to_merge_p85
+from
+  firsts
+group by
+  repo_group
+order by
+  name asc
+;
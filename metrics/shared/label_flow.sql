@@ -0,0 +1,124 @@
+with period_events as (
+  select i.id as issue_id,
+    i.event_id,
+    i.updated_at,
+    i.dup_repo_id,
+    i.dup_repo_name
+  from
+    gha_issues i
+  where
+    {{period:i.updated_at}}
+), previous_events as (
+  select distinct on (i.id) i.id as issue_id,
+    i.event_id,
+    i.updated_at,
+    i.dup_repo_id,
+    i.dup_repo_name
+  from
+    gha_issues i,
+    (
+      select issue_id,
+        min(updated_at) as first_updated_at
+      from
+        period_events
+      group by
+        issue_id
+    ) f
+  where
+    i.id = f.issue_id
+    and i.updated_at < f.first_updated_at
+  order by
+    i.id,
+    i.updated_at desc,
+    i.event_id desc
+), issues_events as (
+  select issue_id,
+    event_id,
+    updated_at,
+    dup_repo_id,
+    dup_repo_name
+  from
+    period_events
+  union select issue_id,
+    event_id,
+    updated_at,
+    dup_repo_id,
+    dup_repo_name
+  from
+    previous_events
+), snapshots as (
+  select ie.issue_id,
+    ie.event_id,
+    ie.updated_at,
+    ie.dup_repo_id,
+    ie.dup_repo_name,
+    array(
+      select il.dup_label_name
+      from
+        gha_issues_labels il
+      where
+        il.issue_id = ie.issue_id
+        and il.event_id = ie.event_id
+    ) as labels
+  from
+    issues_events ie
+), changes as (
+  select issue_id,
+    event_id,
+    updated_at,
+    dup_repo_id,
+    dup_repo_name,
+    labels,
+    lag(labels) over (partition by issue_id order by updated_at, event_id) as prev_labels
+  from
+    snapshots
+), transitions as (
+  select c.issue_id,
+    c.event_id,
+    c.dup_repo_id,
+    c.dup_repo_name,
+    coalesce(removed.name, split_part(added.name, '/', 1) || '/(none)') as from_label,
+    added.name as to_label
+  from
+    changes c
+  cross join lateral (
+    select unnest(c.labels) as name
+    except select unnest(c.prev_labels)
+  ) added
+  left join lateral (
+    select unnest(c.prev_labels) as name
+    except select unnest(c.labels)
+  ) removed
+  on
+    split_part(removed.name, '/', 1) = split_part(added.name, '/', 1)
+  where
+    c.prev_labels is not null
+    and {{period:c.updated_at}}
+    and position('/' in added.name) > 0
+)
+select
+  'hlabel_flow,All' as series,
+  from_label || '`' || to_label as transition,
+  count(distinct event_id) as transitions
+from
+  transitions
+group by
+  transition
+union select 'hlabel_flow,' || r.repo_group as series,
+  t.from_label || '`' || t.to_label as transition,
+  count(distinct t.event_id) as transitions
+from
+  transitions t,
+  gha_repos r
+where
+  r.id = t.dup_repo_id
+  and r.name = t.dup_repo_name
+  and r.repo_group is not null
+group by
+  r.repo_group,
+  transition
+order by
+  series asc,
+  transitions desc,
+  transition asc
+;
@@ -0,0 +1,63 @@
+with var as (
+  select coalesce((select value_s from gha_vars where name = 'timeline_start_label'), 'triage/accepted') as label
+), closed as (
+  select issue_id,
+    repo_id,
+    repo_name,
+    max(created_at) as closed_at
+  from
+    gha_issues_timeline
+  where
+    event = 'closed'
+    and created_at >= '{{from}}'
+    and created_at < '{{to}}'
+  group by
+    issue_id,
+    repo_id,
+    repo_name
+), durations as (
+  select c.issue_id,
+    c.repo_id,
+    c.repo_name,
+    extract(epoch from c.closed_at - max(t.created_at)) / 3600 as hours
+  from
+    closed c,
+    gha_issues_timeline t,
+    var
+  where
+    t.issue_id = c.issue_id
+    and t.event = 'labeled'
+    and t.label_name = var.label
+    and t.created_at <= c.closed_at
+  group by
+    c.issue_id,
+    c.repo_id,
+    c.repo_name,
+    c.closed_at
+), groups as (
+  select 'All' as repo_group,
+    hours
+  from
+    durations
+  union all select r.repo_group,
+    d.hours
+  from
+    durations d,
+    gha_repos r
+  where
+    r.id = d.repo_id
+    and r.name = d.repo_name
+    and r.repo_group is not null
+)
+select
+  'lbl2close;' || repo_group || ';issues,hours_med,hours_p85' as name,
+  count(*) as issues,
+  percentile_disc(0.5) within group (order by hours asc) as hours_med,
+  percentile_disc(0.85) within group (order by hours asc) as hours_p85
+from
+  groups
+group by
+  repo_group
+order by
+  name asc
+;
@@ -0,0 +1,56 @@
+with prs as (
+  select distinct on (pr.id) pr.id,
+    pr.user_id,
+    pr.created_at,
+    pr.dup_repo_id,
+    pr.dup_repo_name
+  from
+    gha_pull_requests pr
+  where
+    pr.created_at >= '{{from}}'
+    and pr.created_at < '{{to}}'
+    and (lower(pr.dup_user_login) {{exclude_bots}})
+  order by
+    pr.id,
+    pr.event_id desc
+), classified as (
+  select pr.id,
+    coalesce(r.repo_group, '') as repo_group,
+    case
+      when is_repo_maintainer(pr.user_id, pr.dup_repo_id, pr.created_at) then 'maintainers'
+      when is_org_member(pr.user_id, pr.created_at) then 'members'
+      else 'contributors'
+    end as kind
+  from
+    prs pr
+  left join
+    gha_repos r
+  on
+    r.id = pr.dup_repo_id
+    and r.name = pr.dup_repo_name
+), groups as (
+  select 'All' as repo_group,
+    id,
+    kind
+  from
+    classified
+  union all select repo_group,
+    id,
+    kind
+  from
+    classified
+  where
+    repo_group != ''
+)
+select
+  'mcontrib;' || repo_group || ';maintainers,members,contributors' as name,
+  round(count(distinct id) filter (where kind = 'maintainers') / {{n}}, 2) as maintainers,
+  round(count(distinct id) filter (where kind = 'members') / {{n}}, 2) as members,
+  round(count(distinct id) filter (where kind = 'contributors') / {{n}}, 2) as contributors
+from
+  groups
+group by
+  repo_group
+order by
+  name asc
+;
@@ -0,0 +1,31 @@
+with milestones as (
+  select distinct on (id) id,
+    title,
+    state,
+    open_issues,
+    closed_issues,
+    closed_at
+  from
+    gha_milestones
+  where
+    updated_at < '{{to}}'
+    and created_at < '{{to}}'
+  order by
+    id,
+    updated_at desc,
+    event_id desc
+)
+select
+  'mburn;' || title || ';open,closed' as name,
+  sum(open_issues) as open,
+  sum(closed_issues) as closed
+from
+  milestones
+where
+  state = 'open'
+  or closed_at >= '{{from}}'
+group by
+  title
+order by
+  name asc
+;
@@ -0,0 +1,37 @@
+with items as (
+  select distinct on (id) id,
+    project_title,
+    coalesce(status, 'No status') as status,
+    archived
+  from
+    gha_project_items
+  where
+    updated_at < '{{to}}'
+    and created_at < '{{to}}'
+  order by
+    id,
+    updated_at desc,
+    event_id desc
+)
+select
+  'pitems,' || project_title || '`' || status as name,
+  count(id) as items
+from
+  items
+where
+  not archived
+group by
+  project_title,
+  status
+union select 'pitems,All`' || status as name,
+  count(id) as items
+from
+  items
+where
+  not archived
+group by
+  status
+order by
+  items desc,
+  name asc
+;
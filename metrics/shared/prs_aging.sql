@@ -0,0 +1,88 @@
+with prs_latest as (
+  select sub.id,
+    sub.created_at,
+    sub.dup_repo_id,
+    sub.dup_repo_name,
+    pr_size(sub.lines) as size
+  from (
+    select id,
+      created_at,
+      closed_at,
+      dup_repo_id,
+      dup_repo_name,
+      coalesce(additions, 0) + coalesce(deletions, 0) as lines,
+      row_number() over (partition by id order by updated_at desc, event_id desc) as rank
+    from
+      gha_pull_requests
+    where
+      created_at < '{{to}}'
+      and updated_at < '{{to}}'
+      and (lower(dup_user_login) {{exclude_bots}})
+  ) sub
+  where
+    sub.rank = 1
+    and (
+      sub.closed_at is null
+      or sub.closed_at >= '{{to}}'
+    )
+), prs as (
+  select pr.id,
+    pr.size,
+    coalesce(r.repo_group, '') as repo_group,
+    case
+      when '{{to}}'::timestamp - pr.created_at < '1 week'::interval then 'lt1w'
+      when '{{to}}'::timestamp - pr.created_at < '4 weeks'::interval then 'w1_4'
+      when '{{to}}'::timestamp - pr.created_at < '3 months'::interval then 'm1_3'
+      else 'gt3m'
+    end as bucket
+  from
+    prs_latest pr
+  left join
+    gha_repos r
+  on
+    r.id = pr.dup_repo_id
+    and r.name = pr.dup_repo_name
+), groups as (
+  select 'All' as repo_group,
+    'All' as size,
+    bucket,
+    id
+  from
+    prs
+  union all select 'All' as repo_group,
+    size,
+    bucket,
+    id
+  from
+    prs
+  union all select repo_group,
+    'All' as size,
+    bucket,
+    id
+  from
+    prs
+  where
+    repo_group != ''
+  union all select repo_group,
+    size,
+    bucket,
+    id
+  from
+    prs
+  where
+    repo_group != ''
+)
+select
+  'pr_aging;' || repo_group || '`' || size || ';lt1w,w1_4,m1_3,gt3m' as name,
+  count(distinct id) filter (where bucket = 'lt1w') as lt1w,
+  count(distinct id) filter (where bucket = 'w1_4') as w1_4,
+  count(distinct id) filter (where bucket = 'm1_3') as m1_3,
+  count(distinct id) filter (where bucket = 'gt3m') as gt3m
+from
+  groups
+group by
+  repo_group,
+  size
+order by
+  name asc
+;
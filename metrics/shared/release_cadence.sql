@@ -0,0 +1,169 @@
+with releases as (
+  select sub.id,
+    sub.dup_repo_id,
+    sub.dup_repo_name,
+    sub.prerelease,
+    sub.released_at,
+    lag(sub.released_at) over (partition by sub.dup_repo_id, sub.prerelease order by sub.released_at asc) as prev_released_at
+  from (
+    select distinct on (id) id,
+      dup_repo_id,
+      dup_repo_name,
+      prerelease,
+      draft,
+      coalesce(published_at, created_at) as released_at
+    from
+      gha_releases
+    where
+      coalesce(published_at, created_at) < '{{to}}'
+    order by
+      id,
+      event_id desc
+  ) sub
+  where
+    not sub.draft
+), releases_period as (
+  select id,
+    dup_repo_id,
+    dup_repo_name,
+    prerelease,
+    released_at,
+    prev_released_at
+  from
+    releases
+  where
+    released_at >= '{{from}}'
+    and released_at < '{{to}}'
+), prs as (
+  select distinct pr.id,
+    pr.dup_repo_id,
+    pr.merged_at
+  from
+    gha_pull_requests pr,
+    (
+      select dup_repo_id,
+        min(prev_released_at) as from_date
+      from
+        releases_period
+      where
+        not prerelease
+      group by
+        dup_repo_id
+    ) rp
+  where
+    pr.dup_repo_id = rp.dup_repo_id
+    and pr.merged_at is not null
+    and pr.merged_at >= coalesce(rp.from_date, pr.merged_at)
+    and pr.merged_at < '{{to}}'
+), leads as (
+  select rel.id as release_id,
+    rel.dup_repo_id,
+    rel.dup_repo_name,
+    extract(epoch from rel.released_at - pr.merged_at) / 3600 as lead_time
+  from
+    releases_period rel,
+    prs pr
+  where
+    not rel.prerelease
+    and pr.dup_repo_id = rel.dup_repo_id
+    and pr.merged_at < rel.released_at
+    and (
+      rel.prev_released_at is null
+      or pr.merged_at >= rel.prev_released_at
+    )
+), repos as (
+  select distinct r.id,
+    r.name,
+    r.repo_group,
+    r.alias
+  from
+    gha_repos r,
+    releases_period rel
+  where
+    r.id = rel.dup_repo_id
+    and r.name = rel.dup_repo_name
+), rels as (
+  select 'relcad;All' as name,
+    id,
+    prerelease
+  from
+    releases_period
+  union all select 'relcad;' || r.repo_group as name,
+    rel.id,
+    rel.prerelease
+  from
+    releases_period rel,
+    repos r
+  where
+    r.id = rel.dup_repo_id
+    and r.name = rel.dup_repo_name
+    and r.repo_group is not null
+  union all select 'relcad_repo;' || r.alias as name,
+    rel.id,
+    rel.prerelease
+  from
+    releases_period rel,
+    repos r
+  where
+    r.id = rel.dup_repo_id
+    and r.name = rel.dup_repo_name
+    and r.alias is not null
+), lts as (
+  select 'relcad;All' as name,
+    lead_time
+  from
+    leads
+  union all select 'relcad;' || r.repo_group as name,
+    l.lead_time
+  from
+    leads l,
+    repos r
+  where
+    r.id = l.dup_repo_id
+    and r.name = l.dup_repo_name
+    and r.repo_group is not null
+  union all select 'relcad_repo;' || r.alias as name,
+    l.lead_time
+  from
+    leads l,
+    repos r
+  where
+    r.id = l.dup_repo_id
+    and r.name = l.dup_repo_name
+    and r.alias is not null
+), stats as (
+  select name,
+    count(distinct id) filter (where not prerelease) as releases,
+    count(distinct id) filter (where prerelease) as prereleases
+  from
+    rels
+  group by
+    name
+), lt_stats as (
+  select name,
+    count(*) as prs,
+    percentile_disc(0.5) within group (order by lead_time asc) as lead_med,
+    percentile_disc(0.85) within group (order by lead_time asc) as lead_p85,
+    percentile_disc(0.95) within group (order by lead_time asc) as lead_p95
+  from
+    lts
+  group by
+    name
+)
+select
+  s.name || ';releases,prereleases,prs,lead_med,lead_p85,lead_p95' as name,
+  s.releases::float / {{n}} as releases,
+  s.prereleases::float / {{n}} as prereleases,
+  coalesce(l.prs, 0) as prs,
+  coalesce(l.lead_med, 0) as lead_med,
+  coalesce(l.lead_p85, 0) as lead_p85,
+  coalesce(l.lead_p95, 0) as lead_p95
+from
+  stats s
+left join
+  lt_stats l
+on
+  l.name = s.name
+order by
+  name asc
+;
@@ -0,0 +1,40 @@
+with stats as (
+  select distinct on (s.repo_id) s.repo_id,
+    s.dup_repo_name,
+    s.stargazers,
+    s.forks,
+    s.subscribers
+  from
+    gha_repo_stats s
+  where
+    s.dt < '{{to}}'
+    and s.dt >= '{{to}}'::timestamp - '1 week'::interval
+  order by
+    s.repo_id,
+    s.dt desc
+)
+select
+  'rstats;All;stars,forks,watchers' as name,
+  sum(stargazers) as stars,
+  sum(forks) as forks,
+  sum(subscribers) as watchers
+from
+  stats
+having
+  count(*) > 0
+union select 'rstats;' || r.repo_group || ';stars,forks,watchers' as name,
+  sum(s.stargazers) as stars,
+  sum(s.forks) as forks,
+  sum(s.subscribers) as watchers
+from
+  stats s,
+  gha_repos r
+where
+  r.id = s.repo_id
+  and r.name = s.dup_repo_name
+  and r.repo_group is not null
+group by
+  r.repo_group
+order by
+  name asc
+;
@@ -0,0 +1,52 @@
+with refs as (
+  select rr.event_id,
+    rr.repo_name,
+    rr.ref_repo_name,
+    r.repo_group,
+    (
+      select rg.repo_group
+      from
+        gha_repos rg
+      where
+        lower(rg.name) = rr.ref_repo_name
+      order by
+        rg.id desc
+      limit 1
+    ) as ref_repo_group
+  from
+    gha_repos_references rr
+  left join
+    gha_repos r
+  on
+    r.id = rr.repo_id
+    and r.name = rr.repo_name
+  where
+    {{period:rr.created_at}}
+    and (lower(rr.actor_login) {{exclude_bots}})
+)
+select
+  'hrepo_refs,repos' as series,
+  lower(repo_name) || ' -> ' || ref_repo_name as pair,
+  count(distinct event_id) as refs
+from
+  refs
+group by
+  pair
+having
+  count(distinct event_id) > 1
+union select 'hrepo_refs,repo_groups' as series,
+  repo_group || ' -> ' || ref_repo_group as pair,
+  count(distinct event_id) as refs
+from
+  refs
+where
+  repo_group is not null
+  and ref_repo_group is not null
+  and repo_group != ref_repo_group
+group by
+  pair
+order by
+  series asc,
+  refs desc,
+  pair asc
+;
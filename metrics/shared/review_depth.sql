@@ -0,0 +1,75 @@
+with prs_latest as (
+  select sub.id,
+    sub.dup_repo_id,
+    sub.dup_repo_name
+  from (
+    select id,
+      dup_repo_id,
+      dup_repo_name,
+      merged_at,
+      row_number() over (partition by id order by updated_at desc, event_id desc) as rank
+    from
+      gha_pull_requests
+    where
+      merged_at >= '{{from}}'
+      and merged_at < '{{to}}'
+  ) sub
+  where
+    sub.rank = 1
+    and sub.merged_at is not null
+), reviews as (
+  select pr.id,
+    pr.dup_repo_id,
+    pr.dup_repo_name,
+    count(distinct c.id) as comments,
+    count(distinct c.pull_request_review_id) as rounds
+  from
+    prs_latest pr
+  left join
+    gha_payloads p
+  on
+    p.pull_request_id = pr.id
+    and p.dup_type = 'PullRequestReviewCommentEvent'
+    and (lower(p.dup_actor_login) {{exclude_bots}})
+  left join
+    gha_comments c
+  on
+    c.id = p.comment_id
+  group by
+    pr.id,
+    pr.dup_repo_id,
+    pr.dup_repo_name
+), reviews_groups as (
+  select r.repo_group,
+    rv.comments,
+    rv.rounds
+  from
+    reviews rv,
+    gha_repos r
+  where
+    r.id = rv.dup_repo_id
+    and r.name = rv.dup_repo_name
+    and r.repo_group is not null
+)
+select
+  'rdepth;All;comments_med,comments_p85,rounds_med,rounds_p85' as name,
+  percentile_disc(0.5) within group (order by comments asc) as comments_med,
+  percentile_disc(0.85) within group (order by comments asc) as comments_p85,
+  percentile_disc(0.5) within group (order by rounds asc) as rounds_med,
+  percentile_disc(0.85) within group (order by rounds asc) as rounds_p85
+from
+  reviews
+having
+  count(*) > 0
+union select 'rdepth;' || repo_group || ';comments_med,comments_p85,rounds_med,rounds_p85' as name,
+  percentile_disc(0.5) within group (order by comments asc) as comments_med,
+  percentile_disc(0.85) within group (order by comments asc) as comments_p85,
+  percentile_disc(0.5) within group (order by rounds asc) as rounds_med,
+  percentile_disc(0.85) within group (order by rounds asc) as rounds_p85
+from
+  reviews_groups
+group by
+  repo_group
+order by
+  name asc
+;
@@ -0,0 +1,66 @@
+with prs as (
+  select distinct on (pr.id) pr.id,
+    pr.user_id,
+    pr.created_at,
+    pr.dup_repo_id,
+    pr.dup_repo_name
+  from
+    gha_pull_requests pr
+  where
+    pr.created_at >= '{{from}}'
+    and pr.created_at < '{{to}}'
+    and (lower(pr.dup_user_login) {{exclude_bots}})
+  order by
+    pr.id,
+    pr.event_id desc
+), reviews as (
+  select pr.id,
+    pr.dup_repo_id,
+    pr.dup_repo_name,
+    extract(epoch from min(rv.submitted_at) - pr.created_at) / 3600 as first_review,
+    extract(epoch from min(rv.submitted_at) filter (where rv.state = 'APPROVED') - pr.created_at) / 3600 as first_approval
+  from
+    prs pr
+  join
+    gha_reviews rv
+  on
+    rv.pull_request_id = pr.id
+    and rv.user_id != pr.user_id
+    and rv.state in ('APPROVED', 'CHANGES_REQUESTED', 'COMMENTED')
+    and (lower(rv.dup_user_login) {{exclude_bots}})
+  group by
+    pr.id,
+    pr.dup_repo_id,
+    pr.dup_repo_name,
+    pr.created_at
+), groups as (
+  select 'All' as repo_group,
+    first_review,
+    first_approval
+  from
+    reviews
+  union all select r.repo_group,
+    rv.first_review,
+    rv.first_approval
+  from
+    reviews rv,
+    gha_repos r
+  where
+    r.id = rv.dup_repo_id
+    and r.name = rv.dup_repo_name
+    and r.repo_group is not null
+)
+select
+  'revlat;' || repo_group || ';prs,review_med,review_p85,approval_med,approval_p85' as name,
+  count(*) as prs,
+  percentile_disc(0.5) within group (order by first_review asc) as review_med,
+  percentile_disc(0.85) within group (order by first_review asc) as review_p85,
+  coalesce(percentile_disc(0.5) within group (order by first_approval asc), 0) as approval_med,
+  coalesce(percentile_disc(0.85) within group (order by first_approval asc), 0) as approval_p85
+from
+  groups
+group by
+  repo_group
+order by
+  name asc
+;
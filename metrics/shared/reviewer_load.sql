@@ -0,0 +1,103 @@
+with requests as (
+  select rr.pull_request_id,
+    rr.requested_reviewer_id as reviewer_id,
+    min(e.created_at) as requested_at
+  from
+    gha_pull_requests_requested_reviewers rr,
+    gha_events e
+  where
+    e.id = rr.event_id
+    and e.created_at < '{{to}}'
+  group by
+    rr.pull_request_id,
+    rr.requested_reviewer_id
+), period_requests as (
+  select rq.pull_request_id,
+    rq.reviewer_id,
+    rq.requested_at,
+    (
+      select min(p.dup_created_at)
+      from
+        gha_payloads p
+      where
+        p.pull_request_id = rq.pull_request_id
+        and p.dup_actor_id = rq.reviewer_id
+        and p.dup_type in ('PullRequestReviewEvent', 'PullRequestReviewCommentEvent')
+        and p.dup_created_at >= rq.requested_at
+    ) as reviewed_at
+  from
+    requests rq
+  where
+    rq.requested_at >= '{{from}}'
+), prs_open as (
+  select sub.id,
+    sub.event_id
+  from (
+    select id,
+      event_id,
+      state,
+      row_number() over (partition by id order by updated_at desc, event_id desc) as rank
+    from
+      gha_pull_requests
+    where
+      updated_at < '{{to}}'
+  ) sub
+  where
+    sub.rank = 1
+    and sub.state = 'open'
+), open_requests as (
+  select rr.requested_reviewer_id as reviewer_id,
+    count(distinct rr.pull_request_id) as open_requests
+  from
+    prs_open pr,
+    gha_pull_requests_requested_reviewers rr
+  where
+    rr.pull_request_id = pr.id
+    and rr.event_id = pr.event_id
+  group by
+    rr.requested_reviewer_id
+), latencies as (
+  select pr.reviewer_id,
+    count(*) as requests,
+    count(pr.reviewed_at) as reviewed,
+    percentile_disc(0.5) within group (order by extract(epoch from pr.reviewed_at - pr.requested_at) / 3600) as latency_med,
+    percentile_disc(0.85) within group (order by extract(epoch from pr.reviewed_at - pr.requested_at) / 3600) as latency_p85
+  from
+    period_requests pr
+  group by
+    pr.reviewer_id
+), reviewers as (
+  select distinct on (a.id) a.id,
+    a.login
+  from
+    gha_actors a
+  where
+    a.login in (select reviewers_name from treviewers)
+  order by
+    a.id,
+    a.login
+)
+select
+  concat('revload;', r.login, ';requests,reviewed,latency_med,latency_p85,open_requests') as name,
+  round(coalesce(l.requests, 0) / {{n}}, 2) as requests,
+  round(coalesce(l.reviewed, 0) / {{n}}, 2) as reviewed,
+  coalesce(l.latency_med, 0) as latency_med,
+  coalesce(l.latency_p85, 0) as latency_p85,
+  coalesce(o.open_requests, 0) as open_requests
+from
+  reviewers r
+left join
+  latencies l
+on
+  l.reviewer_id = r.id
+left join
+  open_requests o
+on
+  o.reviewer_id = r.id
+where
+  l.reviewer_id is not null
+  or o.reviewer_id is not null
+order by
+  open_requests desc,
+  name asc
+;
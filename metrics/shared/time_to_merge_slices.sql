@@ -0,0 +1,113 @@
+with prs_latest as (
+  select sub.id,
+    sub.user_id,
+    sub.dup_repo_id,
+    sub.dup_repo_name,
+    sub.created_at,
+    sub.merged_at,
+    pr_size(sub.lines) as size
+  from (
+    select id,
+      user_id,
+      dup_repo_id,
+      dup_repo_name,
+      created_at,
+      merged_at,
+      coalesce(additions, 0) + coalesce(deletions, 0) as lines,
+      row_number() over (partition by id order by updated_at desc, event_id desc) as rank
+    from
+      gha_pull_requests
+    where
+      merged_at >= '{{from}}'
+      and merged_at < '{{to}}'
+      and (lower(dup_user_login) {{exclude_bots}})
+  ) sub
+  where
+    sub.rank = 1
+    and sub.merged_at is not null
+), prs as (
+  select pr.id,
+    pr.size,
+    coalesce(r.repo_group, '') as repo_group,
+    case
+      when fc.first_pr_id = pr.id then 'newcomer'
+      else 'returning'
+    end as author,
+    case
+      when af.company_name is null or af.company_name in ('(Unknown)', 'NotFound') then 'unknown'
+      when af.company_name = 'Independent' then 'independent'
+      else 'company'
+    end as affiliation,
+    extract(epoch from pr.merged_at - pr.created_at) / 3600 as ttm
+  from
+    prs_latest pr
+  left join
+    gha_repos r
+  on
+    r.id = pr.dup_repo_id
+    and r.name = pr.dup_repo_name
+  left join
+    gha_first_contributions fc
+  on
+    fc.actor_id = pr.user_id
+    and fc.repo_group = 'All'
+  left join
+    gha_actors_affiliations af
+  on
+    af.actor_id = pr.user_id
+    and af.dt_from <= pr.created_at
+    and af.dt_to > pr.created_at
+), slices as (
+  select id,
+    ttm,
+    'All' as slice,
+    repo_group
+  from
+    prs
+  union all select id,
+    ttm,
+    'size_' || size as slice,
+    repo_group
+  from
+    prs
+  union all select id,
+    ttm,
+    'author_' || author as slice,
+    repo_group
+  from
+    prs
+  union all select id,
+    ttm,
+    'aff_' || affiliation as slice,
+    repo_group
+  from
+    prs
+), groups as (
+  select 'All' as repo_group,
+    slice,
+    id,
+    ttm
+  from
+    slices
+  union all select repo_group,
+    slice,
+    id,
+    ttm
+  from
+    slices
+  where
+    repo_group != ''
+)
+select
+  'ttm_slices;' || repo_group || '`' || slice || ';prs,ttm_med,ttm_p85' as name,
+  count(distinct id) as prs,
+  percentile_disc(0.5) within group (order by ttm asc) as ttm_med,
+  percentile_disc(0.85) within group (order by ttm asc) as ttm_p85
+from
+  groups
+group by
+  repo_group,
+  slice
+order by
+  name asc
+;
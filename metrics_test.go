@@ -71,22 +71,7 @@ func TestMetrics(t *testing.T) {
 	}
 
 	// Load test cases
-	var tests metricTests
-	data, err := lib.ReadFile(&ctx, ctx.TestsYaml)
-	if err != nil {
-		lib.FatalOnError(err)
-		return
-	}
-	lib.FatalOnError(yaml.Unmarshal(data, &tests))
-
-	// Read per project test cases
-	testCases := []metricTestCase{}
-	for _, project := range tests.Projects {
-		if project.ProjectName == ctx.Project {
-			testCases = project.Tests
-			break
-		}
-	}
+	tests, testCases := loadMetricTestCases(&ctx)
 	if len(testCases) < 1 {
 		t.Errorf("no tests defined for '%s' project", ctx.Project)
 	}
@@ -136,6 +121,81 @@ func TestMetrics(t *testing.T) {
 	}
 }
 
+// Benchmarks all metrics (or only those selected via TEST_METRICS)
+// Database with test data is created once per metric, only metric execution is measured
+// Run via devel/bench_metrics.sh to compare results with a stored baseline
+func BenchmarkMetrics(b *testing.B) {
+	rand.Seed(time.Now().UnixNano())
+	// Environment context parse
+	var ctx lib.Ctx
+	ctx.Init()
+	ctx.TestMode = true
+
+	// Do not allow to run benchmarks in "gha" database
+	if ctx.PgDB != "dbtest" {
+		b.Fatalf("benchmarks can only be run on \"dbtest\" database")
+	}
+
+	// Load test cases
+	tests, testCases := loadMetricTestCases(&ctx)
+	if len(testCases) < 1 {
+		b.Fatalf("no tests defined for '%s' project", ctx.Project)
+	}
+
+	// Only selected metrics?
+	selectedMetrics := make(map[string]struct{})
+	testMetrics := os.Getenv("TEST_METRICS")
+	if testMetrics != "" {
+		for _, m := range strings.Split(testMetrics, ",") {
+			selectedMetrics[m] = struct{}{}
+		}
+	}
+
+	// Benchmark test cases
+	for index, test := range testCases {
+		if testMetrics != "" {
+			_, ok := selectedMetrics[test.Metric]
+			if !ok {
+				continue
+			}
+		}
+		test := test
+		prepareMetricTestCase(&test)
+		test.DebugDB = false
+		b.Run(fmt.Sprintf("%03d_%s", index+1, test.Metric), func(b *testing.B) {
+			c, err := setupMetricTestCase(&test, &tests, &ctx)
+			defer func() { teardownMetricTestCase(c, &ctx, &test) }()
+			if err != nil {
+				b.Fatalf("test number %d (%s): %v", index+1, test.Metric, err)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_, err = executeMetric(c, &ctx, test.Metric, test.SQL, test.From, test.To, test.Period, test.N, test.Replaces)
+				if err != nil {
+					b.Fatalf("test number %d (%s): %v", index+1, test.Metric, err)
+				}
+			}
+			b.StopTimer()
+		})
+	}
+}
+
+// Loads all test cases and test cases defined for the current project
+func loadMetricTestCases(ctx *lib.Ctx) (tests metricTests, testCases []metricTestCase) {
+	data, err := lib.ReadFile(ctx, ctx.TestsYaml)
+	lib.FatalOnError(err)
+	lib.FatalOnError(yaml.Unmarshal(data, &tests))
+
+	// Read per project test cases
+	for _, project := range tests.Projects {
+		if project.ProjectName == ctx.Project {
+			testCases = project.Tests
+			break
+		}
+	}
+	return
+}
+
 // This prepares raw YAML metric test to be executed:
 // Binds additional setup function(s)
 // if test uses "additional_setup_funcs", "additional_setup_args" section(s)
@@ -338,6 +398,24 @@ func dataForMetricTestCase(con *sql.DB, ctx *lib.Ctx, testMetric *metricTestCase
 				}
 			}
 		}
+		releases, ok := data["releases"]
+		if ok {
+			for _, release := range releases {
+				err = addRelease(con, ctx, release...)
+				if err != nil {
+					return
+				}
+			}
+		}
+		requestedReviewers, ok := data["requested_reviewers"]
+		if ok {
+			for _, requestedReviewer := range requestedReviewers {
+				err = addRequestedReviewer(con, ctx, requestedReviewer...)
+				if err != nil {
+					return
+				}
+			}
+		}
 	}
 	return
 }
@@ -348,6 +426,32 @@ func dataForMetricTestCase(con *sql.DB, ctx *lib.Ctx, testMetric *metricTestCase
 // It also creates full DB structure - without indexes - they're not needed in
 // small databases - like the ones created by test covergae tools
 func executeMetricTestCase(testMetric *metricTestCase, tests *metricTests, ctx *lib.Ctx) (result [][]interface{}, err error) {
+	// Create database with test data, drop it after tests
+	c, err := setupMetricTestCase(testMetric, tests, ctx)
+	defer func() { teardownMetricTestCase(c, ctx, testMetric) }()
+	if err != nil {
+		return
+	}
+
+	// Execute metric and get its results
+	result, err = executeMetric(
+		c,
+		ctx,
+		testMetric.Metric,
+		testMetric.SQL,
+		testMetric.From,
+		testMetric.To,
+		testMetric.Period,
+		testMetric.N,
+		testMetric.Replaces,
+	)
+
+	return
+}
+
+// This creates test database with full DB structure and test data for a single metric
+// Returned connection (if not nil) must be passed to teardownMetricTestCase
+func setupMetricTestCase(testMetric *metricTestCase, tests *metricTests, ctx *lib.Ctx) (c *sql.DB, err error) {
 	// Drop database if exists
 	lib.DropDatabaseIfExists(ctx)
 
@@ -361,15 +465,8 @@ func executeMetricTestCase(testMetric *metricTestCase, tests *metricTests, ctx *
 		return
 	}
 
-	// Drop database after tests
-	if !testMetric.DebugDB {
-		// Drop database after tests
-		defer func() { lib.DropDatabaseIfExists(ctx) }()
-	}
-
 	// Connect to Postgres DB
-	c := lib.PgConn(ctx)
-	defer func() { lib.FatalOnError(c.Close()) }()
+	c = lib.PgConn(ctx)
 
 	// Create DB structure
 	lib.Structure(ctx)
@@ -396,23 +493,19 @@ func executeMetricTestCase(testMetric *metricTestCase, tests *metricTests, ctx *
 			return
 		}
 	}
-
-	// Execute metric and get its results
-	result, err = executeMetric(
-		c,
-		ctx,
-		testMetric.Metric,
-		testMetric.SQL,
-		testMetric.From,
-		testMetric.To,
-		testMetric.Period,
-		testMetric.N,
-		testMetric.Replaces,
-	)
-
 	return
 }
 
+// This closes connection and drops test database (unless in debugDB mode)
+func teardownMetricTestCase(c *sql.DB, ctx *lib.Ctx, testMetric *metricTestCase) {
+	if c != nil {
+		lib.FatalOnError(c.Close())
+	}
+	if !testMetric.DebugDB {
+		lib.DropDatabaseIfExists(ctx)
+	}
+}
+
 // random string
 func randString() string {
 	return fmt.Sprintf("%d", rand.Uint64())
@@ -1047,6 +1140,64 @@ func addMilestone(con *sql.DB, ctx *lib.Ctx, args ...interface{}) (err error) {
 	return
 }
 
+// Add release
+// id, event_id, tag_name, draft, prerelease, created_at, published_at, repo_id, repo_name
+func addRelease(con *sql.DB, ctx *lib.Ctx, args ...interface{}) (err error) {
+	if len(args) != 9 {
+		err = fmt.Errorf("addRelease: expects 9 variadic parameters, got %d %+v", len(args), args)
+		return
+	}
+	newArgs := lib.AnyArray{
+		args[0],        // id
+		args[1],        // event_id
+		args[2],        // tag_name
+		"master",       // target_commitish
+		args[2],        // name
+		args[3],        // draft
+		0,              // author_id
+		args[4],        // prerelease
+		args[5],        // created_at
+		args[6],        // published_at
+		"",             // body
+		0,              // dup_actor_id
+		"",             // dup_actor_login
+		args[7],        // dup_repo_id
+		args[8],        // dup_repo_name
+		"ReleaseEvent", // dup_type
+		args[5],        // dup_created_at
+		"",             // dup_author_login
+	}
+	_, err = lib.ExecSQL(
+		con,
+		ctx,
+		"insert into gha_releases("+
+			"id, event_id, tag_name, target_commitish, name, draft, author_id, prerelease, "+
+			"created_at, published_at, body, "+
+			"dup_actor_id, dup_actor_login, dup_repo_id, dup_repo_name, dup_type, dup_created_at, "+
+			"dup_author_login) "+lib.NValues(18),
+		newArgs...,
+	)
+	return
+}
+
+// Add PR requested reviewer
+// pull_request_id, event_id, requested_reviewer_id
+func addRequestedReviewer(con *sql.DB, ctx *lib.Ctx, args ...interface{}) (err error) {
+	if len(args) != 3 {
+		err = fmt.Errorf("addRequestedReviewer: expects 3 variadic parameters, got %d %+v", len(args), args)
+		return
+	}
+	_, err = lib.ExecSQL(
+		con,
+		ctx,
+		"insert into gha_pull_requests_requested_reviewers("+
+			"pull_request_id, event_id, requested_reviewer_id"+
+			") "+lib.NValues(3),
+		args...,
+	)
+	return
+}
+
 // Helper function - save data structure to YAML
 // Used when migrating test coverage from go source to yaml file
 func interfaceToYaml(fn string, i *[][]interface{}) (err error) {
@@ -1084,6 +1235,31 @@ func (metricTestCase) SetDates(con *sql.DB, ctx *lib.Ctx, arg string, replaces [
 	return
 }
 
+// Set milestones open and closed issues counts after loaded static YAML data
+// Each update is: event_id;open_issues;closed_issues
+func (metricTestCase) SetMilestonesIssues(con *sql.DB, ctx *lib.Ctx, arg string, replaces [][]string) (err error) {
+	updates := strings.Split(arg, ",")
+	for _, update := range updates {
+		ary := strings.Split(strings.TrimSpace(update), ";")
+		if len(ary) != 3 {
+			err = fmt.Errorf("SetMilestonesIssues: expects event_id;open_issues;closed_issues, got '%s'", update)
+			return
+		}
+		_, err = lib.ExecSQL(
+			con,
+			ctx,
+			"update gha_milestones set open_issues = $1, closed_issues = $2 where event_id = $3",
+			ary[1],
+			ary[2],
+			ary[0],
+		)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
 // Sets Repo alias to be the same as Name on all repos
 func (metricTestCase) UpdateRepoAliasFromName(con *sql.DB, ctx *lib.Ctx, arg string, replaces [][]string) (err error) {
 	_, err = lib.ExecSQL(con, ctx, "update gha_repos set alias = name")
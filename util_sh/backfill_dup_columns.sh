@@ -0,0 +1,91 @@
+#!/bin/bash
+# Recomputes denormalized dup_* columns from their source tables (gha_events, gha_actors, gha_repos) in date batches.
+# First fills missing gha_events.dup_actor_login/dup_repo_name, then copies event's actor, repo, type and created_at into all tables having those dup_* columns.
+# Only rows with values different from the source are updated, so it is safe to run it multiple times.
+# PG_DB=... - database to repair, default gha
+# FROM='2015-08-01' - start date, default the oldest event date
+# TO='2019-01-01' - end date, default now
+# PERIOD='1 week' - batch size, default '1 month'
+# TABLES='gha_issues,gha_comments' - only repair those tables
+if [ -z "$PG_PASS" ]
+then
+  echo "$0: you must provide database password via PG_PASS=..."
+  exit 1
+fi
+if [ -z "$PG_DB" ]
+then
+  PG_DB=gha
+fi
+if [ -z "$PERIOD" ]
+then
+  PERIOD='1 month'
+fi
+if [ -z "$FROM" ]
+then
+  FROM=`./devel/db.sh psql "$PG_DB" -tAc "select date_trunc('day', min(created_at)) from gha_events"` || exit 2
+fi
+if [ -z "$TO" ]
+then
+  TO=`./devel/db.sh psql "$PG_DB" -tAc "select now()::timestamp"` || exit 3
+fi
+if [ -z "$FROM" ]
+then
+  echo "$0: no events in $PG_DB"
+  exit 0
+fi
+all='gha_payloads gha_commits gha_pages gha_comments gha_issues gha_milestones gha_forkees gha_releases gha_assets gha_pull_requests gha_issues_labels gha_events_commits_files'
+if [ -z "$TABLES" ]
+then
+  tables=$all
+else
+  tables=${TABLES//,/ }
+fi
+events_sql=`cat util_sql/backfill_dup_events.sql`
+table_sql=`cat util_sql/backfill_dup_columns.sql`
+from=$FROM
+while true
+do
+  to=`./devel/db.sh psql "$PG_DB" -tAc "select least('${from}'::timestamp + '${PERIOD}'::interval, '${TO}'::timestamp)"` || exit 4
+  if [ ! "$from" \< "$to" ]
+  then
+    break
+  fi
+  echo "$PG_DB: $from - $to"
+  sql=${events_sql//\{\{from\}\}/$from}
+  sql=${sql//\{\{to\}\}/$to}
+  echo -n "gha_events: "
+  ./devel/db.sh psql "$PG_DB" -v ON_ERROR_STOP=1 -c "$sql" || exit 5
+  for table in $tables
+  do
+    if [ "$table" = "gha_events_commits_files" ]
+    then
+      cols='repo_id:repo_id repo_name:dup_repo_name type:type created_at:created_at'
+    else
+      cols='actor_id:actor_id actor_login:dup_actor_login repo_id:repo_id repo_name:dup_repo_name type:type created_at:created_at'
+    fi
+    set=''
+    differs=''
+    for col in $cols
+    do
+      dst="dup_${col%:*}"
+      src="e.${col#*:}"
+      if [ -z "$set" ]
+      then
+        set="${dst} = ${src}"
+        differs="t.${dst} is distinct from ${src}"
+      else
+        set="${set}, ${dst} = ${src}"
+        differs="${differs} or t.${dst} is distinct from ${src}"
+      fi
+    done
+    sql=${table_sql//\{\{table\}\}/$table}
+    sql=${sql//\{\{set\}\}/$set}
+    sql=${sql//\{\{differs\}\}/$differs}
+    sql=${sql//\{\{from\}\}/$from}
+    sql=${sql//\{\{to\}\}/$to}
+    echo -n "${table}: "
+    ./devel/db.sh psql "$PG_DB" -v ON_ERROR_STOP=1 -c "$sql" || exit 6
+  done
+  from=$to
+done
+echo "$PG_DB: dup_* columns backfilled from $FROM to $TO"
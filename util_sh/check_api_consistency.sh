@@ -0,0 +1,79 @@
+#!/bin/bash
+# Compares latest DB state of N random issues/PRs from a given repo with their current GitHub API state.
+# Checks: state, milestone, labels, assignees and merged (PRs only).
+# PG_DB=... - database to check, default gha
+# GHA2DB_GITHUB_OAUTH=... - GitHub token or file containing token(s), default /etc/github/oauths then /etc/github/oauth, "-" means public access
+# FIX=1 - call sync_issues for all issues/PRs that differ, so they get new artificial events with the current state
+if ( [ -z "$1" ] || [ -z "$2" ] )
+then
+  echo "Usage: $0 org/repo N"
+  exit 1
+fi
+if [ -z "$PG_PASS" ]
+then
+  echo "$0: you must provide database password via PG_PASS=..."
+  exit 2
+fi
+repo=$1
+n=$2
+if [ -z "$PG_DB" ]
+then
+  PG_DB=gha
+fi
+. ./devel/github_oauth.sh || exit 5
+sql=`cat util_sql/issues_state.sql`
+sql=${sql//\{\{repo\}\}/$repo}
+sql=${sql//\{\{sample\}\}/order by random() limit $n}
+rows=`./devel/db.sh psql "$PG_DB" -tAF $'\x1f' -c "$sql"` || exit 3
+function compare {
+  if [ ! "$2" = "$3" ]
+  then
+    echo "${repo}#${number}: ${1}: db='${2}' api='${3}'"
+    differs=1
+  fi
+}
+checked=0
+diffs=0
+fix=''
+while IFS=$'\x1f' read -r number state milestone labels assignees merged
+do
+  if [ -z "$number" ]
+  then
+    continue
+  fi
+  api=`curl -s -f "${auth[@]}" "https://api.github.com/repos/${repo}/issues/${number}" | jq -r '[.state, (.milestone.title // ""), ([.labels[].name] | sort | join(",")), ([.assignees[].login] | sort | join(","))] | join("\u001f")'`
+  if [ -z "$api" ]
+  then
+    echo "${repo}#${number}: cannot get API state"
+    continue
+  fi
+  IFS=$'\x1f' read -r astate amilestone alabels aassignees <<< "$api"
+  amerged=''
+  if [ ! -z "$merged" ]
+  then
+    amerged=`curl -s -f "${auth[@]}" "https://api.github.com/repos/${repo}/pulls/${number}" | jq -r '.merged'`
+  fi
+  checked=$((checked+1))
+  differs=''
+  compare state "$state" "$astate"
+  compare milestone "$milestone" "$amilestone"
+  compare labels "$labels" "$alabels"
+  compare assignees "$assignees" "$aassignees"
+  compare merged "$merged" "$amerged"
+  if [ ! -z "$differs" ]
+  then
+    diffs=$((diffs+1))
+    if [ -z "$fix" ]
+    then
+      fix="select '${repo}' as dup_repo_name, ${number} as number"
+    else
+      fix="${fix} union select '${repo}', ${number}"
+    fi
+  fi
+done <<< "$rows"
+echo "${repo}: checked ${checked}, differences found in ${diffs}"
+if ( [ ! -z "$FIX" ] && [ ! -z "$fix" ] )
+then
+  echo "${repo}: syncing ${diffs} issues/PRs"
+  GHA2DB_ISSUES_SYNC_SQL="${fix}" GHA2DB_LOCAL=1 GHA2DB_SKIPPDB=1 PG_DB="${PG_DB}" sync_issues || exit 4
+fi
@@ -0,0 +1,33 @@
+#!/bin/bash
+# Removes redundant artificial events: artificial issue/PR state identical to the previous artificial state of the same issue/PR.
+# State is compared on: title, state, closed/locked flags, milestone, assignees, labels and for PRs also merge status and requested reviewers.
+# Requires is_artificial columns, see util_sql/add_is_artificial.sql.
+# DRY_RUN=1 - only report what would be deleted (per repository counts and event IDs), do not delete anything
+# Consider exporting artificial events first using cron/export_artificial.sh
+if [ -z "$1" ]
+then
+  echo "Usage: $0 db_name"
+  exit 1
+fi
+db=$1
+sql="/tmp/$db.clean_artificial.sql"
+function finish {
+  rm -f "$sql"
+}
+trap finish EXIT
+cat util_sql/clean_artificial.sql > "$sql" || exit 2
+if [ -z "$DRY_RUN" ]
+then
+  for tab in gha_texts:event_id gha_issues_events_labels:event_id gha_pull_requests_requested_reviewers:event_id gha_pull_requests_assignees:event_id gha_issues_assignees:event_id gha_issues_labels:event_id gha_milestones:event_id gha_pull_requests:event_id gha_issues:event_id gha_payloads:event_id gha_events:id
+  do
+    t=${tab%:*}
+    c=${tab#*:}
+    echo "delete from $t where $c in (select event_id from clean_artificial);" >> "$sql"
+  done
+  echo "select count(*) || ' redundant artificial events deleted' from clean_artificial;" >> "$sql"
+else
+  echo "select e.dup_repo_name as repo, count(*) as events, min(e.created_at) as first, max(e.created_at) as last from clean_artificial c, gha_events e where e.id = c.event_id group by e.dup_repo_name order by events desc, repo;" >> "$sql"
+  echo "select c.issue_id, c.event_id, c.prev_event_id as same_as_event_id, e.created_at from clean_artificial c, gha_events e where e.id = c.event_id order by c.issue_id, e.created_at;" >> "$sql"
+  echo "select count(*) || ' redundant artificial events would be deleted (dry run)' from clean_artificial;" >> "$sql"
+fi
+db.sh psql "$db" -1 -v ON_ERROR_STOP=1 -f "$sql" || exit 3
@@ -0,0 +1,129 @@
+#!/bin/bash
+# Full backlog drift scan: compares latest DB state of all issues/PRs with GitHub state using batched GraphQL queries.
+# Checks: state, milestone, labels, assignees and merged (PRs only). Meant to be run periodically (for example weekly from cron).
+# PG_DB=... - database to check, default gha
+# REPOS='org1/repo1,org2/repo2' - only check those repos, default all repos that have issues in the database
+# BATCH=50 - number of issues/PRs fetched by a single GraphQL query
+# MIN_POINTS=100 - wait for GraphQL rate limit reset when less points remain
+# GHA2DB_GITHUB_OAUTH=... - GitHub token or file containing token(s), default /etc/github/oauths then /etc/github/oauth (GraphQL requires a token)
+if [ -z "$PG_PASS" ]
+then
+  echo "$0: you must provide database password via PG_PASS=..."
+  exit 1
+fi
+if [ -z "$PG_DB" ]
+then
+  PG_DB=gha
+fi
+if [ -z "$BATCH" ]
+then
+  BATCH=50
+fi
+if [ -z "$MIN_POINTS" ]
+then
+  MIN_POINTS=100
+fi
+. ./devel/github_oauth.sh || exit 5
+if ( [ -z "$oauth" ] || [ "$oauth" = "-" ] )
+then
+  echo "$0: GraphQL API requires a GitHub token"
+  exit 2
+fi
+if [ -z "$REPOS" ]
+then
+  repos=`./devel/db.sh psql "$PG_DB" -tAc "select distinct dup_repo_name from gha_issues order by 1"` || exit 3
+else
+  repos=${REPOS//,/ }
+fi
+fields='state milestone { title } labels(first: 100) { nodes { name } } assignees(first: 100) { nodes { login } }'
+jqf='.data.repository | to_entries[] | select(.value != null) | [(.key | ltrimstr("i")), (.value.state | ascii_downcase | sub("merged"; "closed")), (.value.milestone.title // ""), ([.value.labels.nodes[].name] | sort | join(",")), ([.value.assignees.nodes[].login] | sort | join(",")), (if .value.merged == null then "" else (.value.merged | tostring) end)] | join("\u001f")'
+function graphql {
+  query=`jq -n --arg q "$1" '{query: $q}'`
+  result=`curl -s -f -H "Authorization: bearer ${oauth}" -X POST -d "$query" https://api.github.com/graphql`
+  remaining=`echo "$result" | jq -r '.data.rateLimit.remaining // 0'`
+  if [ "$remaining" -lt "$MIN_POINTS" ]
+  then
+    reset=`echo "$result" | jq -r '.data.rateLimit.resetAt // empty'`
+    if [ ! -z "$reset" ]
+    then
+      wait=$((`date -d "$reset" +%s` - `date +%s` + 5))
+      if [ "$wait" -gt "0" ]
+      then
+        echo "${remaining} GraphQL points left, waiting ${wait}s for reset at ${reset}" >&2
+        sleep $wait
+      fi
+    fi
+  fi
+  echo "$result" | jq -r "$jqf"
+}
+function check_batch {
+  if [ -z "$1" ]
+  then
+    return
+  fi
+  api=`graphql "query { repository(owner: \"${owner}\", name: \"${name}\") { $1 } rateLimit { remaining resetAt } }"`
+  while IFS=$'\x1f' read -r number astate amilestone alabels aassignees amerged
+  do
+    if ( [ -z "$number" ] || [ -z "${db[$number]+x}" ] )
+    then
+      continue
+    fi
+    IFS=$'\x1f' read -r state milestone labels assignees merged <<< "${db[$number]}"
+    checked=$((checked+1))
+    differs=''
+    compare state "$state" "$astate"
+    compare milestone "$milestone" "$amilestone"
+    compare labels "$labels" "$alabels"
+    compare assignees "$assignees" "$aassignees"
+    compare merged "$merged" "$amerged"
+    if [ ! -z "$differs" ]
+    then
+      diffs=$((diffs+1))
+    fi
+  done <<< "$api"
+}
+function compare {
+  if [ ! "$2" = "$3" ]
+  then
+    echo "${repo}#${number}: ${1}: db='${2}' api='${3}'"
+    differs=1
+  fi
+}
+all_checked=0
+all_diffs=0
+for repo in $repos
+do
+  owner=${repo%%/*}
+  name=${repo#*/}
+  sql=`cat util_sql/issues_state.sql`
+  sql=${sql//\{\{repo\}\}/$repo}
+  sql=${sql//\{\{sample\}\}/}
+  rows=`./devel/db.sh psql "$PG_DB" -tAF $'\x1f' -c "$sql"` || exit 4
+  declare -A db=()
+  checked=0
+  diffs=0
+  batch=''
+  n=0
+  while IFS=$'\x1f' read -r number rest
+  do
+    if [ -z "$number" ]
+    then
+      continue
+    fi
+    db[$number]="$rest"
+    batch="${batch} i${number}: issueOrPullRequest(number: ${number}) { ... on Issue { ${fields} } ... on PullRequest { merged ${fields} } }"
+    n=$((n+1))
+    if [ "$n" -ge "$BATCH" ]
+    then
+      check_batch "$batch"
+      batch=''
+      n=0
+    fi
+  done <<< "$rows"
+  check_batch "$batch"
+  unset db
+  echo "${repo}: checked ${checked}, differences found in ${diffs}"
+  all_checked=$((all_checked+checked))
+  all_diffs=$((all_diffs+diffs))
+done
+echo "Total: checked ${all_checked}, differences found in ${all_diffs}"
@@ -0,0 +1,13 @@
+#!/bin/bash
+if [ -z "$1" ]
+then
+  echo "$0: you must specify maximum number of attempts, for example 5"
+  exit 1
+fi
+if [ -z "$PG_PASS" ]
+then
+  echo "$0: you must provide database password via PG_PASS=..., you can select non-default database via PG_DB=..."
+  exit 2
+fi
+GHA2DB_SKIPTIME=1 GHA2DB_SKIPLOG=1 runq util_sql/move_dead_letters.sql {{max_attempts}} "$1" || exit 3
+GHA2DB_SKIPTIME=1 GHA2DB_SKIPLOG=1 runq util_sql/dead_letters.sql || exit 4
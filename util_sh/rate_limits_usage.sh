@@ -0,0 +1,12 @@
+#!/bin/bash
+if [ -z "$1" ]
+then
+  echo "$0: you must specify period, for example '1 week'"
+  exit 1
+fi
+if [ -z "$PG_PASS" ]
+then
+  echo "$0: you must provide database password via PG_PASS=..."
+  exit 2
+fi
+GHA2DB_SKIPTIME=1 GHA2DB_SKIPLOG=1 PG_DB=devstats runq util_sql/rate_limits_usage.sql {{period}} "$1"
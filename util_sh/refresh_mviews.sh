@@ -0,0 +1,50 @@
+#!/bin/bash
+# Creates missing materialized views declared in util_sql/mviews.txt (in dependency order) and then refreshes all materialized views using cron/refresh_mviews.sh.
+# Declared views' schemas and helper functions must already exist, for current_state use util_sql/current_state_all.sql.
+# SKIPREFRESH=1 - only create missing views
+if [ -z "$PG_PASS" ]
+then
+  echo "$0: you need to set PG_PASS environment variable to run this script"
+  exit 1
+fi
+db=$1
+if [ -z "$db" ]
+then
+  db=gha
+fi
+pairs=''
+declare -A files
+while read -r view file deps
+do
+  if ( [ -z "$view" ] || [ "${view:0:1}" = "#" ] )
+  then
+    continue
+  fi
+  files[$view]=$file
+  pairs="${pairs}${view} ${view}"$'\n'
+  for dep in $deps
+  do
+    pairs="${pairs}${dep} ${view}"$'\n'
+  done
+done < util_sql/mviews.txt
+order=`echo -n "$pairs" | tsort` || exit 2
+for view in $order
+do
+  file=${files[$view]}
+  if [ -z "$file" ]
+  then
+    echo "$0: $view is used as a dependency but not declared"
+    exit 3
+  fi
+  exists=`./devel/db.sh psql "$db" -tAc "select to_regclass('${view}') is not null"` || exit 4
+  if [ "$exists" = "t" ]
+  then
+    continue
+  fi
+  echo "$db: creating $view using $file"
+  ./devel/db.sh psql "$db" -v ON_ERROR_STOP=1 -f "$file" || exit 5
+done
+if [ -z "$SKIPREFRESH" ]
+then
+  PATH="${PATH}:./devel" ./cron/refresh_mviews.sh "$db" || exit 6
+fi
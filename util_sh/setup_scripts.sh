@@ -14,6 +14,52 @@ fi
 proj=$GHA2DB_PROJECT
 echo "Setting up $proj repository groups sync script"
 PG_USER="${user}" ./devel/db.sh psql $PG_DB -c "insert into gha_postprocess_scripts(ord, path) select 0, 'scripts/$proj/repo_groups.sql' on conflict do nothing"
+echo "Adding $proj reactions counters columns"
+GHA2DB_LOCAL=1 runq util_sql/add_reactions_counters.sql
+echo "Creating $proj gha_actors_logins table"
+GHA2DB_LOCAL=1 runq util_sql/actors_logins_table.sql
+echo "Creating $proj gha_unknown_events table"
+GHA2DB_LOCAL=1 runq util_sql/unknown_events_table.sql
+echo "Creating $proj gha_commits_unique table"
+GHA2DB_LOCAL=1 runq util_sql/commits_unique_table.sql
+echo "Creating $proj gha_repos_references table"
+GHA2DB_LOCAL=1 runq util_sql/repos_references_table.sql
+echo "Creating $proj gha_first_contributions table"
+GHA2DB_LOCAL=1 runq util_sql/first_contributions_table.sql
+echo "Creating $proj gha_reactions table"
+GHA2DB_LOCAL=1 runq util_sql/reactions_table.sql
+echo "Creating $proj pr_size function"
+GHA2DB_LOCAL=1 runq util_sql/pr_size_func.sql
+echo "Creating $proj gha_repos_traffic and gha_repos_referrers tables"
+GHA2DB_LOCAL=1 runq util_sql/repos_traffic_tables.sql
+echo "Creating $proj is_artificial columns"
+GHA2DB_LOCAL=1 runq util_sql/add_is_artificial.sql
+echo "Creating $proj gha_failed_writes table"
+GHA2DB_LOCAL=1 runq util_sql/failed_writes_table.sql
+echo "Creating $proj gha_dead_letters table"
+GHA2DB_LOCAL=1 runq util_sql/dead_letters_table.sql
+echo "Creating $proj gha_repos_dependencies table"
+GHA2DB_LOCAL=1 runq util_sql/repos_dependencies_table.sql
+echo "Creating $proj gha_orgs_members table"
+GHA2DB_LOCAL=1 runq util_sql/orgs_members_table.sql
+echo "Creating $proj gha_repo_stats table"
+GHA2DB_LOCAL=1 runq util_sql/repo_stats_table.sql
+echo "Creating $proj gha_deployments and gha_deployments_statuses tables"
+GHA2DB_LOCAL=1 runq util_sql/deployments_table.sql
+echo "Creating $proj gha_reviews table"
+GHA2DB_LOCAL=1 runq util_sql/reviews_table.sql
+echo "Creating $proj gha_issues_timeline table"
+GHA2DB_LOCAL=1 runq util_sql/issues_timeline_table.sql
+echo "Creating $proj gha_project_items table"
+GHA2DB_LOCAL=1 runq util_sql/project_items_table.sql
+echo "Creating $proj gha_orgs_teams tables"
+GHA2DB_LOCAL=1 runq util_sql/orgs_teams_table.sql
+echo "Creating $proj labels definitions table"
+GHA2DB_LOCAL=1 runq util_sql/labels_definitions_table.sql
+echo "Creating $proj sync progress table"
+GHA2DB_LOCAL=1 runq util_sql/sync_progress_table.sql
+echo "Creating $proj repos watermarks table"
+GHA2DB_LOCAL=1 runq util_sql/repos_watermarks_table.sql
 echo "Setting $proj up default postprocess scripts"
 GHA2DB_LOCAL=1 runq util_sql/default_postprocess_scripts.sql
 echo "Setting $proj up repository groups postprocess script"
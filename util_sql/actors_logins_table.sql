@@ -0,0 +1,34 @@
+create table if not exists gha_actors_logins(
+  actor_id bigint not null,
+  login character varying(120) not null,
+  first_seen timestamp without time zone not null,
+  last_seen timestamp without time zone not null,
+  primary key(actor_id, login)
+);
+alter table gha_actors_logins owner to gha_admin;
+create index if not exists actors_logins_actor_id_idx on gha_actors_logins using btree (actor_id);
+create index if not exists actors_logins_login_idx on gha_actors_logins using btree (login);
+create index if not exists actors_logins_lower_login_idx on gha_actors_logins using btree (lower(login));
+create index if not exists actors_logins_last_seen_idx on gha_actors_logins using btree (last_seen);
+
+create or replace function public.canonical_login(some_login text) returns text
+  language sql stable
+  as $_$
+select coalesce(
+  (
+    select l2.login
+    from
+      gha_actors_logins l1,
+      gha_actors_logins l2
+    where
+      lower(l1.login) = lower($1)
+      and l2.actor_id = l1.actor_id
+    order by
+      l1.last_seen desc,
+      l2.last_seen desc
+    limit 1
+  ),
+  $1
+);
+$_$;
+alter function public.canonical_login(some_login text) owner to gha_admin;
@@ -0,0 +1,24 @@
+with renamed as (
+  select actor_id
+  from
+    gha_actors_logins
+  group by
+    actor_id
+  having
+    count(distinct login) > 1
+)
+select
+  l.actor_id,
+  l.login,
+  l.first_seen,
+  l.last_seen,
+  public.canonical_login(l.login) as current_login
+from
+  gha_actors_logins l,
+  renamed r
+where
+  l.actor_id = r.actor_id
+order by
+  l.actor_id asc,
+  l.first_seen asc
+;
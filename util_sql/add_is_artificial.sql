@@ -0,0 +1,39 @@
+alter table gha_events add column if not exists is_artificial boolean;
+alter table gha_issues add column if not exists is_artificial boolean;
+alter table gha_pull_requests add column if not exists is_artificial boolean;
+update gha_events set is_artificial = id > 281474976710656 where is_artificial is null;
+update gha_issues set is_artificial = event_id > 281474976710656 where is_artificial is null;
+update gha_pull_requests set is_artificial = event_id > 281474976710656 where is_artificial is null;
+
+create or replace function public.set_event_is_artificial() returns trigger
+  language plpgsql
+  as $_$
+begin
+  new.is_artificial := coalesce(new.is_artificial, new.id > 281474976710656);
+  return new;
+end;
+$_$;
+alter function public.set_event_is_artificial() owner to gha_admin;
+create or replace function public.set_event_id_is_artificial() returns trigger
+  language plpgsql
+  as $_$
+begin
+  new.is_artificial := coalesce(new.is_artificial, new.event_id > 281474976710656);
+  return new;
+end;
+$_$;
+alter function public.set_event_id_is_artificial() owner to gha_admin;
+drop trigger if exists events_is_artificial on gha_events;
+create trigger events_is_artificial before insert on gha_events for each row execute procedure set_event_is_artificial();
+drop trigger if exists issues_is_artificial on gha_issues;
+create trigger issues_is_artificial before insert on gha_issues for each row execute procedure set_event_id_is_artificial();
+drop trigger if exists pull_requests_is_artificial on gha_pull_requests;
+create trigger pull_requests_is_artificial before insert on gha_pull_requests for each row execute procedure set_event_id_is_artificial();
+
+alter table gha_events alter column is_artificial set not null;
+alter table gha_issues alter column is_artificial set not null;
+alter table gha_pull_requests alter column is_artificial set not null;
+
+create index if not exists events_is_artificial_idx on gha_events using btree (id) where is_artificial;
+create index if not exists issues_is_artificial_idx on gha_issues using btree (event_id) where is_artificial;
+create index if not exists pull_requests_is_artificial_idx on gha_pull_requests using btree (event_id) where is_artificial;
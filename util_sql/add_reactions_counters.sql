@@ -0,0 +1,12 @@
+alter table gha_issues add column if not exists reactions_plus_one int not null default 0;
+alter table gha_issues add column if not exists reactions_minus_one int not null default 0;
+alter table gha_issues add column if not exists reactions_total int not null default 0;
+
+alter table gha_pull_requests add column if not exists reactions_plus_one int not null default 0;
+alter table gha_pull_requests add column if not exists reactions_minus_one int not null default 0;
+alter table gha_pull_requests add column if not exists reactions_total int not null default 0;
+
+create index if not exists issues_reactions_plus_one_idx on gha_issues using btree (reactions_plus_one);
+create index if not exists issues_reactions_total_idx on gha_issues using btree (reactions_total);
+create index if not exists pull_requests_reactions_plus_one_idx on gha_pull_requests using btree (reactions_plus_one);
+create index if not exists pull_requests_reactions_total_idx on gha_pull_requests using btree (reactions_total);
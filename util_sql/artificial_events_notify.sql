@@ -0,0 +1,24 @@
+create or replace function public.notify_artificial_event() returns trigger
+  language plpgsql
+  as $_$
+begin
+  perform pg_notify(
+    'devstats_' || current_database(),
+    json_build_object(
+      'id', new.id,
+      'type', new.type,
+      'repo_id', new.repo_id,
+      'repo', new.dup_repo_name,
+      'created_at', new.created_at
+    )::text
+  );
+  return new;
+end;
+$_$;
+alter function public.notify_artificial_event() owner to gha_admin;
+drop trigger if exists events_notify_artificial on gha_events;
+create trigger events_notify_artificial
+  after insert on gha_events
+  for each row
+  when (new.id > 281474976710656)
+  execute procedure public.notify_artificial_event();
@@ -0,0 +1,12 @@
+update
+  {{table}} t
+set
+  {{set}}
+from
+  gha_events e
+where
+  t.event_id = e.id
+  and e.created_at >= '{{from}}'
+  and e.created_at < '{{to}}'
+  and ({{differs}})
+;
@@ -0,0 +1,19 @@
+update
+  gha_events e
+set
+  dup_actor_login = coalesce(
+    (select a.login from gha_actors a where a.id = e.actor_id order by a.login limit 1),
+    e.dup_actor_login
+  ),
+  dup_repo_name = coalesce(
+    (select r.name from gha_repos r where r.id = e.repo_id order by r.name limit 1),
+    e.dup_repo_name
+  )
+where
+  e.created_at >= '{{from}}'
+  and e.created_at < '{{to}}'
+  and (
+    coalesce(e.dup_actor_login, '') = ''
+    or coalesce(e.dup_repo_name, '') = ''
+  )
+;
@@ -0,0 +1,54 @@
+create temp table clean_artificial as
+with seq as (
+  select id as issue_id,
+    event_id,
+    is_artificial,
+    lag(event_id) over w as prev_event_id,
+    lag(is_artificial) over w as prev_is_artificial
+  from
+    gha_issues
+  window
+    w as (partition by id order by updated_at, event_id)
+), cand as (
+  select issue_id,
+    event_id,
+    prev_event_id
+  from
+    seq
+  where
+    is_artificial
+    and prev_is_artificial
+), st as (
+  select i.id as issue_id,
+    i.event_id,
+    md5(row(
+      i.title, i.state, i.closed_at, i.locked, i.milestone_id, i.assignee_id,
+      (select string_agg(il.label_id::text, ',' order by il.label_id) from gha_issues_labels il where il.event_id = i.event_id and il.issue_id = i.id),
+      (select string_agg(ia.assignee_id::text, ',' order by ia.assignee_id) from gha_issues_assignees ia where ia.event_id = i.event_id and ia.issue_id = i.id),
+      pr.id, pr.title, pr.state, pr.closed_at, pr.merged, pr.merged_at, pr.merged_by_id, pr.milestone_id,
+      (select string_agg(pa.assignee_id::text, ',' order by pa.assignee_id) from gha_pull_requests_assignees pa where pa.event_id = pr.event_id and pa.pull_request_id = pr.id),
+      (select string_agg(prr.requested_reviewer_id::text, ',' order by prr.requested_reviewer_id) from gha_pull_requests_requested_reviewers prr where prr.event_id = pr.event_id and prr.pull_request_id = pr.id)
+    )::text) as sig
+  from
+    gha_issues i
+  left join
+    gha_pull_requests pr
+  on
+    pr.event_id = i.event_id
+  where
+    i.event_id in (select event_id from cand union select prev_event_id from cand)
+)
+select c.issue_id,
+  c.event_id,
+  c.prev_event_id
+from
+  cand c,
+  st s,
+  st p
+where
+  s.issue_id = c.issue_id
+  and s.event_id = c.event_id
+  and p.issue_id = c.issue_id
+  and p.event_id = c.prev_event_id
+  and s.sig = p.sig
+;
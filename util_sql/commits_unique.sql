@@ -0,0 +1,26 @@
+select
+  c.dup_repo_name as repo,
+  count(c.sha) as pushed_commits,
+  count(distinct c.sha) as unique_commits,
+  count(c.sha) - count(distinct c.sha) as duplicates,
+  (
+    select count(cu.sha)
+    from
+      gha_commits_unique cu
+    where
+      cu.dup_repo_id = c.dup_repo_id
+      and cu.first_seen >= now() - '{{period}}'::interval
+  ) as new_commits
+from
+  gha_commits c
+where
+  c.dup_type = 'PushEvent'
+  and c.dup_created_at >= now() - '{{period}}'::interval
+group by
+  c.dup_repo_id,
+  c.dup_repo_name
+order by
+  duplicates desc,
+  pushed_commits desc
+limit {{lim}}
+;
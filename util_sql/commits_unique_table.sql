@@ -0,0 +1,20 @@
+create table if not exists gha_commits_unique(
+  sha character varying(40) not null,
+  dup_repo_id bigint not null,
+  dup_repo_name character varying(160) not null,
+  author_name character varying(160) not null,
+  author_email character varying(160) not null,
+  dup_author_login character varying(120) not null,
+  dup_actor_login character varying(120) not null,
+  first_event_id bigint not null,
+  last_event_id bigint not null,
+  first_seen timestamp without time zone not null,
+  last_seen timestamp without time zone not null,
+  primary key(sha, dup_repo_id)
+);
+alter table gha_commits_unique owner to gha_admin;
+create index if not exists commits_unique_sha_idx on gha_commits_unique using btree (sha);
+create index if not exists commits_unique_dup_repo_id_idx on gha_commits_unique using btree (dup_repo_id);
+create index if not exists commits_unique_dup_repo_name_idx on gha_commits_unique using btree (dup_repo_name);
+create index if not exists commits_unique_first_seen_idx on gha_commits_unique using btree (first_seen);
+create index if not exists commits_unique_last_seen_idx on gha_commits_unique using btree (last_seen);
@@ -0,0 +1,16 @@
+select
+  prog,
+  kind,
+  count(*) as items,
+  min(dt) as oldest,
+  max(moved_at) as last_moved
+from
+  gha_dead_letters
+group by
+  prog,
+  kind
+order by
+  items desc,
+  prog asc,
+  kind asc
+;
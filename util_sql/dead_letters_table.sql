@@ -0,0 +1,20 @@
+create table if not exists gha_dead_letters(
+  id bigint not null,
+  dt timestamp without time zone not null,
+  prog character varying(32) not null,
+  kind character varying(40) not null,
+  object_id bigint,
+  event_id bigint,
+  payload jsonb not null,
+  error text not null,
+  attempts int not null,
+  last_attempt timestamp without time zone not null,
+  moved_at timestamp without time zone not null default now(),
+  primary key(id)
+);
+alter table gha_dead_letters owner to gha_admin;
+create index if not exists dead_letters_dt_idx on gha_dead_letters using btree (dt);
+create index if not exists dead_letters_prog_idx on gha_dead_letters using btree (prog);
+create index if not exists dead_letters_kind_idx on gha_dead_letters using btree (kind);
+create index if not exists dead_letters_object_id_idx on gha_dead_letters using btree (object_id);
+create index if not exists dead_letters_moved_at_idx on gha_dead_letters using btree (moved_at);
@@ -2,3 +2,9 @@ insert into gha_postprocess_scripts(ord, path) select 1, 'util_sql/postprocess_t
 insert into gha_postprocess_scripts(ord, path) select 2, 'util_sql/postprocess_labels.sql' on conflict do nothing;
 insert into gha_postprocess_scripts(ord, path) select 3, 'util_sql/postprocess_issues_prs.sql' on conflict do nothing;
 insert into gha_postprocess_scripts(ord, path) select 6, 'util_sql/postprocess_commits.sql' on conflict do nothing;
+insert into gha_postprocess_scripts(ord, path) select 7, 'util_sql/postprocess_actors_logins.sql' on conflict do nothing;
+insert into gha_postprocess_scripts(ord, path) select 8, 'util_sql/postprocess_unknown_events.sql' on conflict do nothing;
+insert into gha_postprocess_scripts(ord, path) select 9, 'util_sql/postprocess_commits_unique.sql' on conflict do nothing;
+insert into gha_postprocess_scripts(ord, path) select 10, 'util_sql/postprocess_first_contributions.sql' on conflict do nothing;
+insert into gha_postprocess_scripts(ord, path) select 11, 'util_sql/postprocess_repos_references.sql' on conflict do nothing;
+insert into gha_postprocess_scripts(ord, path) select 12, 'util_sql/postprocess_reactions.sql' on conflict do nothing;
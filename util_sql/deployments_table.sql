@@ -0,0 +1,38 @@
+create table if not exists gha_deployments(
+  id bigint not null,
+  dup_repo_id bigint not null,
+  dup_repo_name character varying(160) not null,
+  sha character varying(40) not null,
+  ref character varying(200) not null,
+  task character varying(100) not null,
+  environment character varying(100) not null,
+  description text,
+  creator_id bigint not null,
+  dup_creator_login character varying(120) not null,
+  created_at timestamp without time zone not null,
+  updated_at timestamp without time zone not null,
+  primary key(id)
+);
+alter table gha_deployments owner to gha_admin;
+create index if not exists deployments_dup_repo_id_idx on gha_deployments using btree (dup_repo_id);
+create index if not exists deployments_dup_repo_name_idx on gha_deployments using btree (dup_repo_name);
+create index if not exists deployments_sha_idx on gha_deployments using btree (sha);
+create index if not exists deployments_environment_idx on gha_deployments using btree (environment);
+create index if not exists deployments_creator_id_idx on gha_deployments using btree (creator_id);
+create index if not exists deployments_created_at_idx on gha_deployments using btree (created_at);
+
+create table if not exists gha_deployments_statuses(
+  id bigint not null,
+  deployment_id bigint not null,
+  state character varying(20) not null,
+  environment character varying(100),
+  description text,
+  creator_id bigint not null,
+  dup_creator_login character varying(120) not null,
+  created_at timestamp without time zone not null,
+  primary key(id)
+);
+alter table gha_deployments_statuses owner to gha_admin;
+create index if not exists deployments_statuses_deployment_id_idx on gha_deployments_statuses using btree (deployment_id);
+create index if not exists deployments_statuses_state_idx on gha_deployments_statuses using btree (state);
+create index if not exists deployments_statuses_created_at_idx on gha_deployments_statuses using btree (created_at);
@@ -0,0 +1,17 @@
+create table gha_rate_limits(
+  dt timestamp without time zone not null default now(),
+  prog character varying(32) not null,
+  proj character varying(32) not null,
+  run_dt timestamp without time zone not null,
+  phase character varying(40) not null default '',
+  token character varying(16) not null default '',
+  lim int not null,
+  remaining int not null,
+  reset_at timestamp without time zone not null
+);
+alter table gha_rate_limits owner to gha_admin;
+create index rate_limits_dt_idx on gha_rate_limits using btree (dt);
+create index rate_limits_prog_idx on gha_rate_limits using btree (prog);
+create index rate_limits_proj_idx on gha_rate_limits using btree (proj);
+create index rate_limits_run_dt_idx on gha_rate_limits using btree (run_dt);
+create index rate_limits_token_idx on gha_rate_limits using btree (token);
@@ -0,0 +1,2 @@
+drop trigger if exists events_notify_artificial on gha_events;
+drop function if exists public.notify_artificial_event();
@@ -0,0 +1,17 @@
+select
+  prog,
+  kind,
+  count(*) as items,
+  min(dt) as oldest,
+  max(last_attempt) as last_attempt,
+  max(attempts) as max_attempts
+from
+  gha_failed_writes
+group by
+  prog,
+  kind
+order by
+  items desc,
+  prog asc,
+  kind asc
+;
@@ -0,0 +1,19 @@
+create table if not exists gha_failed_writes(
+  id bigserial not null,
+  dt timestamp without time zone not null default now(),
+  prog character varying(32) not null,
+  kind character varying(40) not null,
+  object_id bigint,
+  event_id bigint,
+  payload jsonb not null,
+  error text not null,
+  attempts int not null default 1,
+  last_attempt timestamp without time zone not null default now(),
+  primary key(id)
+);
+alter table gha_failed_writes owner to gha_admin;
+create index if not exists failed_writes_dt_idx on gha_failed_writes using btree (dt);
+create index if not exists failed_writes_prog_idx on gha_failed_writes using btree (prog);
+create index if not exists failed_writes_kind_idx on gha_failed_writes using btree (kind);
+create index if not exists failed_writes_object_id_idx on gha_failed_writes using btree (object_id);
+create index if not exists failed_writes_attempts_idx on gha_failed_writes using btree (attempts);
@@ -0,0 +1,14 @@
+create table if not exists gha_first_contributions(
+  actor_id bigint not null,
+  repo_group character varying(80) not null,
+  dup_actor_login character varying(120) not null,
+  first_pr_id bigint not null,
+  first_pr_at timestamp without time zone not null,
+  first_merged_pr_id bigint,
+  first_merged_at timestamp without time zone,
+  primary key(actor_id, repo_group)
+);
+alter table gha_first_contributions owner to gha_admin;
+create index if not exists first_contributions_repo_group_idx on gha_first_contributions using btree (repo_group);
+create index if not exists first_contributions_first_pr_at_idx on gha_first_contributions using btree (first_pr_at);
+create index if not exists first_contributions_first_merged_at_idx on gha_first_contributions using btree (first_merged_at);
@@ -0,0 +1,89 @@
+with issues as (
+  select distinct on (i.id)
+    i.id,
+    i.event_id,
+    i.number,
+    i.state,
+    i.is_pull_request,
+    i.milestone_id,
+    i.dup_repo_name
+  from
+    gha_issues i
+  where
+    i.dup_repo_name = '{{repo}}'
+  order by
+    i.id,
+    i.updated_at desc,
+    i.event_id desc
+), sample as (
+  select
+    *
+  from
+    issues
+  {{sample}}
+)
+select
+  s.number,
+  s.state,
+  coalesce(
+    (
+      select m.title
+      from
+        gha_milestones m
+      where
+        m.id = s.milestone_id
+      order by
+        m.updated_at desc,
+        m.event_id desc
+      limit 1
+    ),
+    ''
+  ) as milestone,
+  coalesce(
+    (
+      select string_agg(il.dup_label_name, ',' order by il.dup_label_name collate "C")
+      from
+        gha_issues_labels il
+      where
+        il.issue_id = s.id
+        and il.event_id = s.event_id
+    ),
+    ''
+  ) as labels,
+  coalesce(
+    (
+      select string_agg(sub.login, ',' order by sub.login collate "C")
+      from (
+        select (select a.login from gha_actors a where a.id = ia.assignee_id order by a.login limit 1) as login
+        from
+          gha_issues_assignees ia
+        where
+          ia.issue_id = s.id
+          and ia.event_id = s.event_id
+      ) sub
+    ),
+    ''
+  ) as assignees,
+  case s.is_pull_request
+    when true then coalesce(
+      (
+        select (pr.merged_at is not null)::text
+        from
+          gha_pull_requests pr
+        where
+          pr.dup_repo_name = s.dup_repo_name
+          and pr.number = s.number
+        order by
+          pr.updated_at desc,
+          pr.event_id desc
+        limit 1
+      ),
+      'false'
+    )
+    else ''
+  end as merged
+from
+  sample s
+order by
+  s.number
+;
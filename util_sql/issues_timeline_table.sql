@@ -0,0 +1,29 @@
+create table if not exists gha_issues_timeline(
+  id bigint not null,
+  issue_id bigint not null,
+  event character varying(40) not null,
+  created_at timestamp without time zone not null,
+  actor_id bigint,
+  actor_login character varying(120),
+  label_name character varying(160),
+  milestone_title character varying(200),
+  assignee_id bigint,
+  assignee_login character varying(120),
+  rename_from text,
+  rename_to text,
+  source_issue_id bigint,
+  commit_id character varying(40),
+  repo_id bigint not null,
+  repo_name character varying(160) not null,
+  issue_number int not null,
+  primary key(issue_id, id)
+);
+alter table gha_issues_timeline owner to gha_admin;
+create index if not exists issues_timeline_event_idx on gha_issues_timeline using btree (event);
+create index if not exists issues_timeline_created_at_idx on gha_issues_timeline using btree (created_at);
+create index if not exists issues_timeline_actor_id_idx on gha_issues_timeline using btree (actor_id);
+create index if not exists issues_timeline_label_name_idx on gha_issues_timeline using btree (label_name);
+create index if not exists issues_timeline_milestone_title_idx on gha_issues_timeline using btree (milestone_title);
+create index if not exists issues_timeline_source_issue_id_idx on gha_issues_timeline using btree (source_issue_id);
+create index if not exists issues_timeline_repo_id_idx on gha_issues_timeline using btree (repo_id);
+create index if not exists issues_timeline_repo_name_idx on gha_issues_timeline using btree (repo_name);
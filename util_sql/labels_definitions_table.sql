@@ -0,0 +1,33 @@
+create table if not exists gha_labels_definitions(
+  id bigint not null,
+  dup_repo_id bigint not null,
+  dup_repo_name character varying(160) not null,
+  name character varying(160) not null,
+  color character varying(8) not null,
+  description text,
+  dt_from timestamp without time zone not null,
+  dt_to timestamp without time zone not null default '2100-01-01',
+  primary key(id, dt_from)
+);
+alter table gha_labels_definitions owner to gha_admin;
+create index if not exists labels_definitions_dup_repo_id_idx on gha_labels_definitions using btree (dup_repo_id);
+create index if not exists labels_definitions_dup_repo_name_idx on gha_labels_definitions using btree (dup_repo_name);
+create index if not exists labels_definitions_name_idx on gha_labels_definitions using btree (name);
+create index if not exists labels_definitions_dt_from_idx on gha_labels_definitions using btree (dt_from);
+create index if not exists labels_definitions_dt_to_idx on gha_labels_definitions using btree (dt_to);
+
+create or replace function public.label_names(lname text) returns setof text
+  language sql stable
+  as $_$
+select distinct
+  d2.name
+from
+  gha_labels_definitions d1,
+  gha_labels_definitions d2
+where
+  d1.name = $1
+  and d2.id = d1.id
+union select $1
+;
+$_$;
+alter function public.label_names(lname text) owner to gha_admin;
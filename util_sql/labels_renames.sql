@@ -0,0 +1,21 @@
+select
+  dup_repo_name as repo,
+  prev_name as old_name,
+  name as new_name,
+  dt_from as renamed_at
+from (
+  select dup_repo_name,
+    name,
+    dt_from,
+    lag(name) over (partition by id order by dt_from asc) as prev_name
+  from
+    gha_labels_definitions
+) sub
+where
+  prev_name is not null
+  and prev_name != name
+order by
+  renamed_at desc,
+  repo asc,
+  old_name asc
+;
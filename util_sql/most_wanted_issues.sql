@@ -0,0 +1,23 @@
+select
+  i.dup_repo_name as repo,
+  i.number,
+  i.title,
+  i.reactions_plus_one as plus_one,
+  i.reactions_minus_one as minus_one,
+  i.reactions_total as total
+from
+  gha_issues i
+where
+  i.is_pull_request = false
+  and i.state = 'open'
+  and i.event_id = (
+    select inn.event_id from gha_issues inn where inn.id = i.id order by inn.updated_at desc, inn.event_id desc limit 1
+  )
+  and i.reactions_plus_one > 0
+order by
+  i.reactions_plus_one - i.reactions_minus_one desc,
+  i.reactions_total desc,
+  repo asc,
+  i.number asc
+limit {{lim}}
+;
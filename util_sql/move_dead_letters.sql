@@ -0,0 +1,24 @@
+with moved as (
+  delete from
+    gha_failed_writes
+  where
+    attempts >= {{max_attempts}}
+  returning
+    id, dt, prog, kind, object_id, event_id, payload, error, attempts, last_attempt
+), inserted as (
+  insert into gha_dead_letters(
+    id, dt, prog, kind, object_id, event_id, payload, error, attempts, last_attempt
+  )
+  select
+    id, dt, prog, kind, object_id, event_id, payload, error, attempts, last_attempt
+  from
+    moved
+  on conflict do nothing
+  returning
+    id
+)
+select
+  count(*) as moved
+from
+  inserted
+;
@@ -0,0 +1,34 @@
+create table if not exists gha_orgs_members(
+  org_id bigint not null,
+  actor_id bigint not null,
+  dup_org_login character varying(100) not null,
+  dup_actor_login character varying(120) not null,
+  public boolean not null,
+  dt_from timestamp without time zone not null,
+  dt_to timestamp without time zone not null default '2100-01-01',
+  primary key(org_id, actor_id, dt_from)
+);
+alter table gha_orgs_members owner to gha_admin;
+create index if not exists orgs_members_org_id_idx on gha_orgs_members using btree (org_id);
+create index if not exists orgs_members_actor_id_idx on gha_orgs_members using btree (actor_id);
+create index if not exists orgs_members_dup_org_login_idx on gha_orgs_members using btree (dup_org_login);
+create index if not exists orgs_members_dup_actor_login_idx on gha_orgs_members using btree (dup_actor_login);
+create index if not exists orgs_members_public_idx on gha_orgs_members using btree (public);
+create index if not exists orgs_members_dt_from_idx on gha_orgs_members using btree (dt_from);
+create index if not exists orgs_members_dt_to_idx on gha_orgs_members using btree (dt_to);
+
+create or replace function public.is_org_member(aid bigint, dt timestamp without time zone, only_public boolean default false) returns boolean
+  language sql stable
+  as $_$
+select exists(
+  select 1
+  from
+    gha_orgs_members
+  where
+    actor_id = $1
+    and dt_from <= $2
+    and dt_to > $2
+    and (not $3 or public)
+);
+$_$;
+alter function public.is_org_member(aid bigint, dt timestamp without time zone, only_public boolean) owner to gha_admin;
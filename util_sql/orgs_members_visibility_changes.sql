@@ -0,0 +1,24 @@
+select
+  m.dup_org_login as org,
+  m.dup_actor_login as member,
+  case m.public when true then 'public' else 'private' end as visibility,
+  m.dt_from,
+  case m.dt_to when '2100-01-01' then null else m.dt_to end as dt_to
+from
+  gha_orgs_members m
+where
+  (m.org_id, m.actor_id) in (
+    select org_id, actor_id
+    from
+      gha_orgs_members
+    group by
+      org_id,
+      actor_id
+    having
+      count(distinct public) > 1
+  )
+order by
+  org asc,
+  member asc,
+  m.dt_from asc
+;
@@ -0,0 +1,72 @@
+create table if not exists gha_orgs_teams(
+  id bigint not null,
+  org_id bigint not null,
+  dup_org_login character varying(100) not null,
+  slug character varying(100) not null,
+  name character varying(200) not null,
+  parent_id bigint,
+  privacy character varying(20) not null,
+  updated_at timestamp without time zone not null default now(),
+  primary key(id)
+);
+alter table gha_orgs_teams owner to gha_admin;
+create index if not exists orgs_teams_org_id_idx on gha_orgs_teams using btree (org_id);
+create index if not exists orgs_teams_dup_org_login_idx on gha_orgs_teams using btree (dup_org_login);
+create index if not exists orgs_teams_slug_idx on gha_orgs_teams using btree (slug);
+create index if not exists orgs_teams_parent_id_idx on gha_orgs_teams using btree (parent_id);
+
+create table if not exists gha_orgs_teams_members(
+  team_id bigint not null,
+  actor_id bigint not null,
+  dup_team_slug character varying(100) not null,
+  dup_actor_login character varying(120) not null,
+  role character varying(20) not null,
+  dt_from timestamp without time zone not null,
+  dt_to timestamp without time zone not null default '2100-01-01',
+  primary key(team_id, actor_id, dt_from)
+);
+alter table gha_orgs_teams_members owner to gha_admin;
+create index if not exists orgs_teams_members_team_id_idx on gha_orgs_teams_members using btree (team_id);
+create index if not exists orgs_teams_members_actor_id_idx on gha_orgs_teams_members using btree (actor_id);
+create index if not exists orgs_teams_members_dup_actor_login_idx on gha_orgs_teams_members using btree (dup_actor_login);
+create index if not exists orgs_teams_members_dt_from_idx on gha_orgs_teams_members using btree (dt_from);
+create index if not exists orgs_teams_members_dt_to_idx on gha_orgs_teams_members using btree (dt_to);
+
+create table if not exists gha_orgs_teams_repos(
+  team_id bigint not null,
+  repo_id bigint not null,
+  dup_team_slug character varying(100) not null,
+  dup_repo_name character varying(160) not null,
+  permission character varying(20) not null,
+  dt_from timestamp without time zone not null,
+  dt_to timestamp without time zone not null default '2100-01-01',
+  primary key(team_id, repo_id, dt_from)
+);
+alter table gha_orgs_teams_repos owner to gha_admin;
+create index if not exists orgs_teams_repos_team_id_idx on gha_orgs_teams_repos using btree (team_id);
+create index if not exists orgs_teams_repos_repo_id_idx on gha_orgs_teams_repos using btree (repo_id);
+create index if not exists orgs_teams_repos_dup_repo_name_idx on gha_orgs_teams_repos using btree (dup_repo_name);
+create index if not exists orgs_teams_repos_permission_idx on gha_orgs_teams_repos using btree (permission);
+create index if not exists orgs_teams_repos_dt_from_idx on gha_orgs_teams_repos using btree (dt_from);
+create index if not exists orgs_teams_repos_dt_to_idx on gha_orgs_teams_repos using btree (dt_to);
+
+create or replace function public.is_repo_maintainer(aid bigint, rid bigint, dt timestamp without time zone) returns boolean
+  language sql stable
+  as $_$
+select exists(
+  select 1
+  from
+    gha_orgs_teams_members tm,
+    gha_orgs_teams_repos tr
+  where
+    tm.actor_id = $1
+    and tr.team_id = tm.team_id
+    and tr.repo_id = $2
+    and tr.permission in ('push', 'maintain', 'admin')
+    and tm.dt_from <= $3
+    and tm.dt_to > $3
+    and tr.dt_from <= $3
+    and tr.dt_to > $3
+);
+$_$;
+alter function public.is_repo_maintainer(aid bigint, rid bigint, dt timestamp without time zone) owner to gha_admin;
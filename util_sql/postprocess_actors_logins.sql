@@ -0,0 +1,24 @@
+with var as (
+  select coalesce(max(last_seen), '1970-01-01 00:00:00') as last_seen
+  from
+    gha_actors_logins
+)
+insert into gha_actors_logins(actor_id, login, first_seen, last_seen)
+select
+  actor_id,
+  dup_actor_login,
+  min(created_at),
+  max(created_at)
+from
+  gha_events
+where
+  actor_id > 0
+  and dup_actor_login != ''
+  and created_at >= (select last_seen from var)
+group by
+  actor_id,
+  dup_actor_login
+on conflict (actor_id, login) do update set
+  first_seen = least(gha_actors_logins.first_seen, excluded.first_seen),
+  last_seen = greatest(gha_actors_logins.last_seen, excluded.last_seen)
+;
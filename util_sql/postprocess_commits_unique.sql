@@ -0,0 +1,43 @@
+with var as (
+  select coalesce(max(last_seen), '1970-01-01 00:00:00') as last_seen
+  from
+    gha_commits_unique
+)
+insert into gha_commits_unique(
+  sha, dup_repo_id, dup_repo_name, author_name, author_email, dup_author_login,
+  dup_actor_login, first_event_id, last_event_id, first_seen, last_seen
+)
+select
+  sha,
+  dup_repo_id,
+  (array_agg(dup_repo_name order by dup_created_at desc, event_id desc))[1],
+  (array_agg(author_name order by dup_created_at, event_id))[1],
+  (array_agg(author_email order by dup_created_at, event_id))[1],
+  (array_agg(dup_author_login order by dup_created_at, event_id))[1],
+  (array_agg(dup_actor_login order by dup_created_at, event_id))[1],
+  min(event_id),
+  max(event_id),
+  min(dup_created_at),
+  max(dup_created_at)
+from
+  gha_commits
+where
+  dup_type = 'PushEvent'
+  and dup_created_at >= (select last_seen from var)
+group by
+  sha,
+  dup_repo_id
+on conflict (sha, dup_repo_id) do update set
+  dup_repo_name = case when excluded.last_seen >= gha_commits_unique.last_seen then excluded.dup_repo_name else gha_commits_unique.dup_repo_name end,
+  author_name = case when excluded.first_seen < gha_commits_unique.first_seen then excluded.author_name else gha_commits_unique.author_name end,
+  author_email = case when excluded.first_seen < gha_commits_unique.first_seen then excluded.author_email else gha_commits_unique.author_email end,
+  dup_author_login = case
+    when gha_commits_unique.dup_author_login = '' then excluded.dup_author_login
+    else gha_commits_unique.dup_author_login
+  end,
+  dup_actor_login = case when excluded.first_seen < gha_commits_unique.first_seen then excluded.dup_actor_login else gha_commits_unique.dup_actor_login end,
+  first_event_id = least(gha_commits_unique.first_event_id, excluded.first_event_id),
+  last_event_id = greatest(gha_commits_unique.last_event_id, excluded.last_event_id),
+  first_seen = least(gha_commits_unique.first_seen, excluded.first_seen),
+  last_seen = greatest(gha_commits_unique.last_seen, excluded.last_seen)
+;
@@ -0,0 +1,87 @@
+with var as (
+  select coalesce(max(dt), '1970-01-01 00:00:00') as dt
+  from
+    gha_computed
+  where
+    metric = 'first_contributions'
+), mark as (
+  insert into gha_computed(metric, dt)
+  select 'first_contributions',
+    max(updated_at)
+  from
+    gha_pull_requests
+  having
+    max(updated_at) is not null
+  on conflict do nothing
+  returning dt
+), prs as (
+  select pr.id,
+    pr.user_id,
+    pr.dup_user_login,
+    pr.created_at,
+    pr.merged_at,
+    r.repo_group
+  from
+    gha_pull_requests pr
+  left join
+    gha_repos r
+  on
+    r.id = pr.dup_repo_id
+    and r.name = pr.dup_repo_name
+  where
+    pr.updated_at >= (select dt from var)
+    and pr.user_id > 0
+), firsts as (
+  select user_id,
+    'All' as repo_group,
+    (array_agg(dup_user_login order by created_at desc))[1] as login,
+    (array_agg(id order by created_at, id))[1] as first_pr_id,
+    min(created_at) as first_pr_at,
+    (array_agg(id order by merged_at, id) filter (where merged_at is not null))[1] as first_merged_pr_id,
+    min(merged_at) as first_merged_at
+  from
+    prs
+  group by
+    user_id
+  union select user_id,
+    repo_group,
+    (array_agg(dup_user_login order by created_at desc))[1] as login,
+    (array_agg(id order by created_at, id))[1] as first_pr_id,
+    min(created_at) as first_pr_at,
+    (array_agg(id order by merged_at, id) filter (where merged_at is not null))[1] as first_merged_pr_id,
+    min(merged_at) as first_merged_at
+  from
+    prs
+  where
+    repo_group is not null
+  group by
+    user_id,
+    repo_group
+)
+insert into gha_first_contributions(actor_id, repo_group, dup_actor_login, first_pr_id, first_pr_at, first_merged_pr_id, first_merged_at)
+select
+  user_id,
+  repo_group,
+  login,
+  first_pr_id,
+  first_pr_at,
+  first_merged_pr_id,
+  first_merged_at
+from
+  firsts
+on conflict (actor_id, repo_group) do update set
+  dup_actor_login = excluded.dup_actor_login,
+  first_pr_id = case when excluded.first_pr_at < gha_first_contributions.first_pr_at then excluded.first_pr_id else gha_first_contributions.first_pr_id end,
+  first_pr_at = least(gha_first_contributions.first_pr_at, excluded.first_pr_at),
+  first_merged_pr_id = case
+    when gha_first_contributions.first_merged_at is null or excluded.first_merged_at < gha_first_contributions.first_merged_at then coalesce(excluded.first_merged_pr_id, gha_first_contributions.first_merged_pr_id)
+    else gha_first_contributions.first_merged_pr_id
+  end,
+  first_merged_at = least(gha_first_contributions.first_merged_at, excluded.first_merged_at)
+;
+delete from
+  gha_computed
+where
+  metric = 'first_contributions'
+  and dt < (select max(dt) from gha_computed where metric = 'first_contributions')
+;
@@ -0,0 +1,115 @@
+with var as (
+  select coalesce(max(dt), '1970-01-01 00:00:00') as dt
+  from
+    gha_computed
+  where
+    metric = 'reactions'
+), mark as (
+  insert into gha_computed(metric, dt)
+  select 'reactions',
+    max(synced_at)
+  from
+    gha_reactions
+  having
+    max(synced_at) is not null
+  on conflict do nothing
+  returning dt
+), subjects as (
+  select distinct subject_id as issue_id
+  from
+    gha_reactions
+  where
+    subject_type = 'issue'
+    and synced_at >= (select dt from var)
+), counts as (
+  select s.issue_id,
+    count(r.id) filter (where r.content = '+1') as plus_one,
+    count(r.id) filter (where r.content = '-1') as minus_one,
+    count(r.id) as total
+  from
+    subjects s
+  left join
+    gha_reactions r
+  on
+    r.subject_type = 'issue'
+    and r.subject_id = s.issue_id
+  group by
+    s.issue_id
+), issues as (
+  select distinct on (i.id) i.id,
+    i.event_id
+  from
+    gha_issues i
+  where
+    i.id in (select issue_id from counts)
+  order by
+    i.id,
+    i.updated_at desc,
+    i.event_id desc
+), upd_issues as (
+  update
+    gha_issues i
+  set
+    reactions_plus_one = c.plus_one,
+    reactions_minus_one = c.minus_one,
+    reactions_total = c.total
+  from
+    issues l,
+    counts c
+  where
+    c.issue_id = l.id
+    and i.id = l.id
+    and i.event_id = l.event_id
+    and (
+      i.reactions_plus_one != c.plus_one
+      or i.reactions_minus_one != c.minus_one
+      or i.reactions_total != c.total
+    )
+  returning i.id
+), pr_counts as (
+  select distinct ipr.pull_request_id,
+    c.plus_one,
+    c.minus_one,
+    c.total
+  from
+    counts c,
+    gha_issues_pull_requests ipr
+  where
+    ipr.issue_id = c.issue_id
+), prs as (
+  select distinct on (pr.id) pr.id,
+    pr.event_id
+  from
+    gha_pull_requests pr
+  where
+    pr.id in (select pull_request_id from pr_counts)
+  order by
+    pr.id,
+    pr.updated_at desc,
+    pr.event_id desc
+)
+update
+  gha_pull_requests pr
+set
+  reactions_plus_one = c.plus_one,
+  reactions_minus_one = c.minus_one,
+  reactions_total = c.total
+from
+  prs l,
+  pr_counts c
+where
+  c.pull_request_id = l.id
+  and pr.id = l.id
+  and pr.event_id = l.event_id
+  and (
+    pr.reactions_plus_one != c.plus_one
+    or pr.reactions_minus_one != c.minus_one
+    or pr.reactions_total != c.total
+  )
+;
+delete from
+  gha_computed
+where
+  metric = 'reactions'
+  and dt < (select max(dt) from gha_computed where metric = 'reactions')
+;
@@ -0,0 +1,33 @@
+with var as (
+  select coalesce(max(created_at), '1970-01-01 00:00:00') as dt
+  from
+    gha_repos_references
+)
+insert into gha_repos_references(event_id, repo_id, repo_name, ref_repo_name, ref_number, actor_login, created_at)
+select distinct
+  sub.event_id,
+  sub.repo_id,
+  sub.repo_name,
+  sub.ref_repo_name,
+  sub.ref_number,
+  sub.actor_login,
+  sub.created_at
+from (
+  select t.event_id,
+    t.repo_id,
+    t.repo_name,
+    lower(m[1]) as ref_repo_name,
+    m[2]::integer as ref_number,
+    t.actor_login,
+    t.created_at
+  from
+    gha_texts t,
+    regexp_matches(t.body, '(?:^|[^A-Za-z0-9_./-])(?:https?://github\.com/)?([A-Za-z0-9][A-Za-z0-9-]*/[A-Za-z0-9_.-]+)(?:#|/issues/|/pull/)([0-9]{1,9})', 'g') as m
+  where
+    t.created_at >= (select dt from var)
+    and t.event_id is not null
+) sub
+where
+  sub.ref_repo_name != lower(sub.repo_name)
+on conflict do nothing
+;
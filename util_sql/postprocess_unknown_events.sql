@@ -0,0 +1,30 @@
+with var as (
+  select coalesce(max(dt), '1970-01-01 00:00:00') as dt
+  from
+    gha_unknown_events
+)
+insert into gha_unknown_events(dt, type, events, first_event_id)
+select
+  date_trunc('hour', created_at),
+  type,
+  count(id),
+  min(id)
+from
+  gha_events
+where
+  created_at >= (select dt from var)
+  and not is_artificial
+  and type not in (
+    'CommitCommentEvent', 'CreateEvent', 'DeleteEvent', 'ForkEvent', 'GollumEvent',
+    'IssueCommentEvent', 'IssuesEvent', 'MemberEvent', 'PublicEvent', 'PullRequestEvent',
+    'PullRequestReviewCommentEvent', 'PullRequestReviewEvent', 'PushEvent', 'ReleaseEvent',
+    'TeamAddEvent', 'WatchEvent'
+  )
+  and type not like 'Art%'
+group by
+  date_trunc('hour', created_at),
+  type
+on conflict (dt, type) do update set
+  events = excluded.events,
+  first_event_id = excluded.first_event_id
+;
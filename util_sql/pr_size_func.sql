@@ -0,0 +1,13 @@
+create or replace function public.pr_size(lines bigint) returns text
+  language sql immutable
+  as $_$
+select case
+  when $1 < 10 then 'xs'
+  when $1 < 30 then 's'
+  when $1 < 100 then 'm'
+  when $1 < 500 then 'l'
+  when $1 < 1000 then 'xl'
+  else 'xxl'
+end;
+$_$;
+alter function public.pr_size(lines bigint) owner to gha_admin;
@@ -0,0 +1,26 @@
+create table if not exists gha_project_items(
+  id bigint not null,
+  event_id bigint not null,
+  node_id character varying(100) not null,
+  project_id bigint not null,
+  project_number int not null,
+  project_title character varying(200) not null,
+  owner_login character varying(120) not null,
+  content_type character varying(20) not null,
+  content_id bigint,
+  status character varying(100),
+  archived boolean not null default false,
+  created_at timestamp without time zone not null,
+  updated_at timestamp without time zone not null,
+  dupn_repo_id bigint,
+  dupn_repo_name character varying(160),
+  primary key(id, event_id)
+);
+alter table gha_project_items owner to gha_admin;
+create index if not exists project_items_event_id_idx on gha_project_items using btree (event_id);
+create index if not exists project_items_project_id_idx on gha_project_items using btree (project_id);
+create index if not exists project_items_content_idx on gha_project_items using btree (content_type, content_id);
+create index if not exists project_items_status_idx on gha_project_items using btree (status);
+create index if not exists project_items_updated_at_idx on gha_project_items using btree (updated_at);
+create index if not exists project_items_dupn_repo_id_idx on gha_project_items using btree (dupn_repo_id);
+create index if not exists project_items_dupn_repo_name_idx on gha_project_items using btree (dupn_repo_name);
@@ -0,0 +1,34 @@
+with samples as (
+  select proj,
+    prog,
+    run_dt,
+    token,
+    reset_at,
+    max(remaining) - min(remaining) as used
+  from
+    gha_rate_limits
+  where
+    dt >= now() - '{{period}}'::interval
+  group by
+    proj,
+    prog,
+    run_dt,
+    token,
+    reset_at
+)
+select
+  proj,
+  prog,
+  count(distinct run_dt) as runs,
+  sum(used) as points_used,
+  round(sum(used)::numeric / greatest(count(distinct run_dt), 1), 2) as points_per_run
+from
+  samples
+group by
+  proj,
+  prog
+order by
+  points_used desc,
+  proj asc,
+  prog asc
+;
@@ -0,0 +1,21 @@
+create table if not exists gha_reactions(
+  id bigint not null,
+  subject_type character varying(20) not null,
+  subject_id bigint not null,
+  content character varying(20) not null,
+  user_id bigint not null,
+  dup_user_login character varying(120) not null,
+  dup_repo_id bigint not null,
+  dup_repo_name character varying(160) not null,
+  created_at timestamp without time zone not null,
+  synced_at timestamp without time zone not null default now(),
+  primary key(id)
+);
+alter table gha_reactions owner to gha_admin;
+create index if not exists reactions_subject_idx on gha_reactions using btree (subject_type, subject_id);
+create index if not exists reactions_content_idx on gha_reactions using btree (content);
+create index if not exists reactions_user_id_idx on gha_reactions using btree (user_id);
+create index if not exists reactions_dup_user_login_idx on gha_reactions using btree (dup_user_login);
+create index if not exists reactions_dup_repo_id_idx on gha_reactions using btree (dup_repo_id);
+create index if not exists reactions_created_at_idx on gha_reactions using btree (created_at);
+create index if not exists reactions_synced_at_idx on gha_reactions using btree (synced_at);
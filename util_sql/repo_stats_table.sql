@@ -0,0 +1,13 @@
+create table if not exists gha_repo_stats(
+  repo_id bigint not null,
+  dt date not null,
+  dup_repo_name character varying(160) not null,
+  stargazers int not null,
+  forks int not null,
+  subscribers int not null,
+  open_issues int not null,
+  primary key(repo_id, dt)
+);
+alter table gha_repo_stats owner to gha_admin;
+create index if not exists repo_stats_dt_idx on gha_repo_stats using btree (dt);
+create index if not exists repo_stats_dup_repo_name_idx on gha_repo_stats using btree (dup_repo_name);
@@ -0,0 +1,59 @@
+with snapshots as (
+  select repo_id,
+    snapshot_dt,
+    lag(snapshot_dt) over (partition by repo_id order by snapshot_dt asc) as prev_dt
+  from (
+    select distinct repo_id,
+      snapshot_dt
+    from
+      gha_repos_dependencies
+    where
+      snapshot_dt >= '{{from}}'
+      and snapshot_dt < '{{to}}'
+  ) sub
+), curr as (
+  select d.repo_id, s.snapshot_dt, s.prev_dt, d.dup_repo_name, d.package_manager, d.name, d.version
+  from
+    gha_repos_dependencies d,
+    snapshots s
+  where
+    d.repo_id = s.repo_id
+    and d.snapshot_dt = s.snapshot_dt
+    and s.prev_dt is not null
+), prev as (
+  select d.repo_id, s.snapshot_dt, s.prev_dt, d.dup_repo_name, d.package_manager, d.name, d.version
+  from
+    gha_repos_dependencies d,
+    snapshots s
+  where
+    d.repo_id = s.repo_id
+    and d.snapshot_dt = s.prev_dt
+)
+select
+  coalesce(c.dup_repo_name, p.dup_repo_name) as repo,
+  coalesce(c.snapshot_dt, p.snapshot_dt) as snapshot_dt,
+  coalesce(c.package_manager, p.package_manager) as package_manager,
+  coalesce(c.name, p.name) as dependency,
+  p.version as old_version,
+  c.version as new_version,
+  case
+    when p.name is null then 'added'
+    when c.name is null then 'removed'
+    else 'changed'
+  end as change
+from
+  curr c
+full outer join
+  prev p
+on
+  c.repo_id = p.repo_id
+  and c.snapshot_dt = p.snapshot_dt
+  and c.package_manager = p.package_manager
+  and c.name = p.name
+where
+  c.version is distinct from p.version
+order by
+  repo asc,
+  snapshot_dt asc,
+  dependency asc
+;
@@ -0,0 +1,18 @@
+create table if not exists gha_repos_dependencies(
+  repo_id bigint not null,
+  snapshot_dt timestamp without time zone not null,
+  dup_repo_name character varying(160) not null,
+  package_manager character varying(40) not null,
+  name character varying(300) not null,
+  version character varying(120) not null default '',
+  license character varying(120),
+  manifest character varying(300) not null default '',
+  primary key(repo_id, snapshot_dt, package_manager, name, version, manifest)
+);
+alter table gha_repos_dependencies owner to gha_admin;
+create index if not exists repos_dependencies_repo_id_idx on gha_repos_dependencies using btree (repo_id);
+create index if not exists repos_dependencies_snapshot_dt_idx on gha_repos_dependencies using btree (snapshot_dt);
+create index if not exists repos_dependencies_dup_repo_name_idx on gha_repos_dependencies using btree (dup_repo_name);
+create index if not exists repos_dependencies_package_manager_idx on gha_repos_dependencies using btree (package_manager);
+create index if not exists repos_dependencies_name_idx on gha_repos_dependencies using btree (name);
+create index if not exists repos_dependencies_license_idx on gha_repos_dependencies using btree (license);
@@ -0,0 +1,14 @@
+create table if not exists gha_repos_references(
+  event_id bigint not null,
+  repo_id bigint not null,
+  repo_name character varying(160) not null,
+  ref_repo_name character varying(160) not null,
+  ref_number integer not null,
+  actor_login character varying(120) not null,
+  created_at timestamp without time zone not null,
+  primary key(event_id, ref_repo_name, ref_number)
+);
+alter table gha_repos_references owner to gha_admin;
+create index if not exists repos_references_repo_name_idx on gha_repos_references using btree (repo_name);
+create index if not exists repos_references_ref_repo_name_idx on gha_repos_references using btree (ref_repo_name);
+create index if not exists repos_references_created_at_idx on gha_repos_references using btree (created_at);
@@ -0,0 +1,29 @@
+create table if not exists gha_repos_traffic(
+  repo_id bigint not null,
+  dt timestamp without time zone not null,
+  dup_repo_name character varying(160) not null,
+  views int not null default 0,
+  unique_views int not null default 0,
+  clones int not null default 0,
+  unique_clones int not null default 0,
+  primary key(repo_id, dt)
+);
+alter table gha_repos_traffic owner to gha_admin;
+create index if not exists repos_traffic_repo_id_idx on gha_repos_traffic using btree (repo_id);
+create index if not exists repos_traffic_dt_idx on gha_repos_traffic using btree (dt);
+create index if not exists repos_traffic_dup_repo_name_idx on gha_repos_traffic using btree (dup_repo_name);
+
+create table if not exists gha_repos_referrers(
+  repo_id bigint not null,
+  dt timestamp without time zone not null,
+  referrer character varying(200) not null,
+  dup_repo_name character varying(160) not null,
+  count int not null,
+  uniques int not null,
+  primary key(repo_id, dt, referrer)
+);
+alter table gha_repos_referrers owner to gha_admin;
+create index if not exists repos_referrers_repo_id_idx on gha_repos_referrers using btree (repo_id);
+create index if not exists repos_referrers_dt_idx on gha_repos_referrers using btree (dt);
+create index if not exists repos_referrers_referrer_idx on gha_repos_referrers using btree (referrer);
+create index if not exists repos_referrers_dup_repo_name_idx on gha_repos_referrers using btree (dup_repo_name);
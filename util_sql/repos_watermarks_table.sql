@@ -0,0 +1,11 @@
+create table if not exists gha_repos_watermarks(
+  repo_id bigint not null,
+  prog character varying(32) not null,
+  dup_repo_name character varying(160) not null,
+  since timestamp without time zone not null,
+  updated_at timestamp without time zone not null default now(),
+  primary key(repo_id, prog)
+);
+alter table gha_repos_watermarks owner to gha_admin;
+create index if not exists repos_watermarks_dup_repo_name_idx on gha_repos_watermarks using btree (dup_repo_name);
+create index if not exists repos_watermarks_since_idx on gha_repos_watermarks using btree (since);
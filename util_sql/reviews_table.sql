@@ -0,0 +1,28 @@
+create table if not exists gha_reviews(
+  id bigint not null,
+  event_id bigint not null,
+  pull_request_id bigint not null,
+  user_id bigint not null,
+  commit_id character varying(40),
+  state character varying(20) not null,
+  author_association character varying(20),
+  submitted_at timestamp without time zone not null,
+  body text,
+  dup_actor_id bigint not null,
+  dup_actor_login character varying(120) not null,
+  dup_repo_id bigint not null,
+  dup_repo_name character varying(160) not null,
+  dup_type character varying(40) not null,
+  dup_created_at timestamp without time zone not null,
+  dup_user_login character varying(120) not null,
+  primary key(id, event_id)
+);
+alter table gha_reviews owner to gha_admin;
+create index if not exists reviews_event_id_idx on gha_reviews using btree (event_id);
+create index if not exists reviews_pull_request_id_idx on gha_reviews using btree (pull_request_id);
+create index if not exists reviews_user_id_idx on gha_reviews using btree (user_id);
+create index if not exists reviews_state_idx on gha_reviews using btree (state);
+create index if not exists reviews_submitted_at_idx on gha_reviews using btree (submitted_at);
+create index if not exists reviews_dup_repo_id_idx on gha_reviews using btree (dup_repo_id);
+create index if not exists reviews_dup_repo_name_idx on gha_reviews using btree (dup_repo_name);
+create index if not exists reviews_dup_user_login_idx on gha_reviews using btree (dup_user_login);
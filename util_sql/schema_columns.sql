@@ -0,0 +1,45 @@
+select
+  c.table_name,
+  c.column_name,
+  case
+    when c.character_maximum_length is not null then c.data_type || '(' || c.character_maximum_length || ')'
+    else c.data_type
+  end as data_type,
+  c.is_nullable,
+  coalesce(c.column_default, ''),
+  case when pk.column_name is not null then 'yes' else '' end as pk,
+  coalesce(
+    col_description((quote_ident(c.table_schema) || '.' || quote_ident(c.table_name))::regclass, c.ordinal_position::int),
+    ''
+  ) as description
+from
+  information_schema.tables t
+join
+  information_schema.columns c
+on
+  c.table_schema = t.table_schema
+  and c.table_name = t.table_name
+left join (
+  select
+    kcu.table_name,
+    kcu.column_name
+  from
+    information_schema.table_constraints tc,
+    information_schema.key_column_usage kcu
+  where
+    tc.constraint_name = kcu.constraint_name
+    and tc.table_schema = kcu.table_schema
+    and tc.table_schema = 'public'
+    and tc.constraint_type = 'PRIMARY KEY'
+) pk
+on
+  pk.table_name = c.table_name
+  and pk.column_name = c.column_name
+where
+  t.table_schema = 'public'
+  and t.table_type = 'BASE TABLE'
+  and t.table_name like 'gha_%'
+order by
+  c.table_name,
+  c.ordinal_position
+;
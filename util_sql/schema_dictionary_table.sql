@@ -0,0 +1,11 @@
+create table if not exists gha_schema_dictionary(
+  table_name character varying(100) not null,
+  column_name character varying(100) not null,
+  data_type character varying(100) not null,
+  nullable boolean not null,
+  description text,
+  source text,
+  updated_at timestamp without time zone not null default now(),
+  primary key(table_name, column_name)
+);
+alter table gha_schema_dictionary owner to gha_admin;
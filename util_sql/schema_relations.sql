@@ -0,0 +1,47 @@
+with conventions(pattern, ref_table, ref_column) as (
+  values
+    ('^(dup_)?(actor|user|assignee|author|committer|creator|owner|uploader|requested_reviewer|reviewer|member|sender)_id$', 'gha_actors', 'id'),
+    ('^dup_(actor|user|assignee|author|committer|creator|owner|uploader)_login$', 'gha_actors', 'login'),
+    ('^(dup_)?repo_id$', 'gha_repos', 'id'),
+    ('^dup_repo_name$', 'gha_repos', 'name'),
+    ('^event_id$', 'gha_events', 'id'),
+    ('^issue_id$', 'gha_issues', 'id'),
+    ('^pull_request_id$', 'gha_pull_requests', 'id'),
+    ('^milestone_id$', 'gha_milestones', 'id'),
+    ('^(dup_)?label_id$', 'gha_labels', 'id'),
+    ('^dup_label_name$', 'gha_labels', 'name'),
+    ('^(dup_)?org_id$', 'gha_orgs', 'id'),
+    ('^dup_org_login$', 'gha_orgs', 'login'),
+    ('^comment_id$', 'gha_comments', 'id'),
+    ('^release_id$', 'gha_releases', 'id'),
+    ('^asset_id$', 'gha_assets', 'id'),
+    ('^forkee_id$', 'gha_forkees', 'id'),
+    ('^team_id$', 'gha_teams', 'id'),
+    ('^company_name$', 'gha_companies', 'name')
+)
+select
+  c.table_name,
+  c.column_name,
+  cv.ref_table,
+  cv.ref_column,
+  case c.column_name like 'dup%' when true then 'dup' else 'ref' end as kind
+from
+  information_schema.columns c,
+  conventions cv
+where
+  c.table_schema = 'public'
+  and c.table_name like 'gha_%'
+  and c.column_name ~ cv.pattern
+  and c.table_name != cv.ref_table
+  and exists (
+    select 1
+    from
+      information_schema.tables t
+    where
+      t.table_schema = 'public'
+      and t.table_name = cv.ref_table
+  )
+order by
+  c.table_name,
+  c.ordinal_position
+;
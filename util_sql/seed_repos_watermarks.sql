@@ -0,0 +1,14 @@
+insert into gha_repos_watermarks(repo_id, prog, dup_repo_name, since)
+select
+  dup_repo_id,
+  'ghapi2db',
+  (array_agg(dup_repo_name order by updated_at desc))[1],
+  max(updated_at)
+from
+  gha_issues
+where
+  is_artificial
+group by
+  dup_repo_id
+on conflict do nothing
+;
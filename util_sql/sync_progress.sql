@@ -0,0 +1,18 @@
+select
+  prog,
+  run_dt,
+  kind,
+  count(*) as processed,
+  min(processed_at) as first_processed,
+  max(processed_at) as last_processed
+from
+  gha_sync_progress
+group by
+  prog,
+  run_dt,
+  kind
+order by
+  run_dt desc,
+  prog asc,
+  kind asc
+;
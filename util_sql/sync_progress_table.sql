@@ -0,0 +1,11 @@
+create table if not exists gha_sync_progress(
+  prog character varying(32) not null,
+  run_dt timestamp without time zone not null,
+  kind character varying(20) not null,
+  object_id bigint not null,
+  processed_at timestamp without time zone not null default now(),
+  primary key(prog, run_dt, kind, object_id)
+);
+alter table gha_sync_progress owner to gha_admin;
+create index if not exists sync_progress_run_dt_idx on gha_sync_progress using btree (run_dt);
+create index if not exists sync_progress_processed_at_idx on gha_sync_progress using btree (processed_at);
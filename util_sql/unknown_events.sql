@@ -0,0 +1,17 @@
+select
+  type,
+  sum(events) as events,
+  count(dt) as hours,
+  min(dt) as first_seen,
+  max(dt) as last_seen,
+  min(first_event_id) as example_event_id
+from
+  gha_unknown_events
+where
+  dt >= now() - '{{period}}'::interval
+group by
+  type
+order by
+  events desc,
+  type asc
+;
@@ -0,0 +1,10 @@
+create table if not exists gha_unknown_events(
+  dt timestamp without time zone not null,
+  type character varying(40) not null,
+  events integer not null,
+  first_event_id bigint not null,
+  primary key(dt, type)
+);
+alter table gha_unknown_events owner to gha_admin;
+create index if not exists unknown_events_dt_idx on gha_unknown_events using btree (dt);
+create index if not exists unknown_events_type_idx on gha_unknown_events using btree (type);